@@ -1,14 +1,24 @@
 package clause
 
 import (
+	"database/sql/driver"
 	"fmt"
+	"reflect"
+	"regexp"
 	"strings"
+	"tsorm/dialect"
 )
 
 // Clause represents a component for constructing SQL statements.
 type Clause struct {
 	sql     map[Type]string        // Stores the SQL statements for each SQL type
 	sqlVars map[Type][]interface{} // Stores the SQL variables for each SQL type
+	dialect dialect.Dialect        // Dialect used to render placeholders, "?" is kept if nil
+}
+
+// New returns an empty Clause that renders its SQL for the given dialect.
+func New(d dialect.Dialect) Clause {
+	return Clause{dialect: d}
 }
 
 // Type represents the type of SQL statement.
@@ -25,6 +35,10 @@ const (
 	UPDATE
 	DELETE
 	COUNT
+	RETURNING
+	OFFSET
+	GROUPBY
+	HAVING
 )
 
 // Set method is used to set the SQL statement and variables for a specific type.
@@ -42,14 +56,14 @@ func (c *Clause) Set(name Type, vars ...interface{}) {
 	}
 
 	// Generate SQL statement and variables.
-	sql, vars := generators[name](vars...)
+	sql, vars := generators[name](c.dialect, vars...)
 	c.sql[name] = sql
 	c.sqlVars[name] = vars
 }
 
 // Build method is used to construct the SQL statement.
 // It takes a series of SQL types as parameters and constructs the corresponding SQL statement according to the specified order.
-// It returns the constructed SQL statement and its associated variables.
+// It returns the constructed SQL statement, with placeholders rebound for the dialect, and its associated variables.
 func (c *Clause) Build(orders ...Type) (string, []interface{}) {
 	// Clean up maps after the method call.
 	defer func() {
@@ -73,11 +87,130 @@ func (c *Clause) Build(orders ...Type) (string, []interface{}) {
 			vars = append(vars, c.sqlVars[order]...)
 		}
 	}
-	return strings.Join(sqls, " "), vars
+	return Rebind(c.dialect, strings.Join(sqls, " ")), vars
+}
+
+// Rebind replaces the "?" placeholders of sql with the bind variables of the dialect.
+// Question marks inside quoted strings and identifiers are left untouched.
+func Rebind(d dialect.Dialect, sql string) string {
+	// Nothing to do for dialects using "?" placeholders.
+	if d == nil || d.BindVar(1) == "?" || !strings.Contains(sql, "?") {
+		return sql
+	}
+	return replacePlaceholders(sql, func(index int) string {
+		return d.BindVar(index + 1)
+	})
+}
+
+// Expand replaces each "?" placeholder of sql whose variable is a slice or array with one
+// placeholder per element, and the variable with its elements, so that "ID IN (?)" works with []int.
+// For an empty slice, "column IN (?)" is replaced with the false predicate "1 = 0" and
+// "column NOT IN (?)" with "1 = 1"; other uses of an empty slice become NULL.
+// Byte slices and arrays and driver.Valuer values are kept as single variables.
+// sql is returned unchanged if its number of "?" placeholders differs from the number of variables.
+func Expand(sql string, vars []interface{}) (string, []interface{}) {
+	expand := false
+	for _, v := range vars {
+		expand = expand || isExpandable(v)
+	}
+	positions := placeholderPositions(sql)
+	if !expand || len(positions) != len(vars) {
+		return sql, vars
+	}
+
+	var b strings.Builder
+	var expanded []interface{}
+	last := 0 // last is the end of the SQL written so far
+	for i, pos := range positions {
+		segment, v := sql[last:pos], vars[i]
+		last = pos + 1
+		if !isExpandable(v) {
+			b.WriteString(segment + "?")
+			expanded = append(expanded, v)
+			continue
+		}
+		rv := reflect.ValueOf(v)
+		if rv.Len() > 0 {
+			b.WriteString(segment + strings.Repeat("?, ", rv.Len()-1) + "?")
+			for j := 0; j < rv.Len(); j++ {
+				expanded = append(expanded, rv.Index(j).Interface())
+			}
+			continue
+		}
+		// Rewrite the whole "column [NOT] IN (?)" of an empty slice.
+		in := emptyInPattern.FindStringSubmatchIndex(segment)
+		closing := closingParenPattern.FindString(sql[last:])
+		if in == nil || closing == "" {
+			b.WriteString(segment + "NULL")
+			continue
+		}
+		b.WriteString(segment[:in[0]])
+		if in[2] >= 0 {
+			b.WriteString("1 = 1")
+		} else {
+			b.WriteString("1 = 0")
+		}
+		last += len(closing)
+	}
+	b.WriteString(sql[last:])
+	return b.String(), expanded
+}
+
+// emptyInPattern matches "column IN (" and "column NOT IN (" at the end of the SQL before a placeholder.
+// closingParenPattern matches the parenthesis closing the list after it.
+var (
+	emptyInPattern      = regexp.MustCompile(`(?i)[\w."$\[\]` + "`" + `]+\s+(NOT\s+)?IN\s*\(\s*$`)
+	closingParenPattern = regexp.MustCompile(`^\s*\)`)
+)
+
+// isExpandable reports whether v is a slice or array to be expanded into several variables.
+func isExpandable(v interface{}) bool {
+	if _, ok := v.(driver.Valuer); ok {
+		return false
+	}
+	t := reflect.TypeOf(v)
+	if t == nil || (t.Kind() != reflect.Slice && t.Kind() != reflect.Array) {
+		return false
+	}
+	return t.Elem().Kind() != reflect.Uint8
+}
+
+// placeholderPositions returns the byte offsets of the "?" placeholders of sql outside quoted strings and identifiers.
+func placeholderPositions(sql string) []int {
+	var positions []int
+	var quote rune // quote is the quote character of the literal being read, 0 outside literals
+	for i, r := range sql {
+		switch {
+		case quote != 0:
+			// Closing the literal; doubled quotes simply reopen it on the next rune.
+			if r == quote {
+				quote = 0
+			}
+		case r == '\'' || r == '"' || r == '`':
+			quote = r
+		case r == '?':
+			positions = append(positions, i)
+		}
+	}
+	return positions
+}
+
+// replacePlaceholders replaces every "?" placeholder of sql outside quoted strings and identifiers
+// with the result of replace, which is given the zero-based index of the placeholder.
+func replacePlaceholders(sql string, replace func(index int) string) string {
+	var b strings.Builder
+	last := 0
+	for i, pos := range placeholderPositions(sql) {
+		b.WriteString(sql[last:pos])
+		b.WriteString(replace(i))
+		last = pos + 1
+	}
+	b.WriteString(sql[last:])
+	return b.String()
 }
 
 // isValidType function is used to check if the provided SQL type is valid.
 // It returns true if valid, false otherwise.
 func isValidType(t Type) bool {
-	return t >= INSERT && t <= COUNT
+	return t >= INSERT && t <= HAVING
 }
@@ -3,6 +3,7 @@ package clause
 import (
 	"reflect"
 	"testing"
+	"tsorm/dialect"
 )
 
 func TestSelect(t *testing.T) {
@@ -30,6 +31,32 @@ func TestSelect(t *testing.T) {
 	}
 }
 
+// TestBuild_Postgres tests that Build rebinds placeholders for the postgres dialect.
+func TestBuild_Postgres(t *testing.T) {
+	postgres, _ := dialect.GetDialect("postgres")
+	clause := New(postgres)
+
+	clause.Set(SELECT, "User", []string{"*"})
+	clause.Set(WHERE, "Name = ? AND Note <> '?'", "Tom")
+	clause.Set(LIMIT, 3)
+	sql, vars := clause.Build(SELECT, WHERE, LIMIT)
+
+	if sql != `SELECT * FROM "User" WHERE Name = $1 AND Note <> '?' LIMIT $2` {
+		t.Fatal("failed to build SQL, got", sql)
+	}
+	if !reflect.DeepEqual(vars, []interface{}{"Tom", 3}) {
+		t.Fatal("failed to build SQLVars, got", vars)
+	}
+
+	// Multi-row inserts number the placeholders across rows.
+	clause.Set(INSERT, "User", []string{"Name", "Age"})
+	clause.Set(VALUES, []interface{}{"Tom", 18}, []interface{}{"Sam", 25})
+	sql, _ = clause.Build(INSERT, VALUES)
+	if sql != `INSERT INTO "User" ("Name","Age") VALUES ($1, $2), ($3, $4)` {
+		t.Fatal("failed to build SQL, got", sql)
+	}
+}
+
 func TestIsValidType(t *testing.T) {
 	tests := []struct {
 		name string
@@ -50,3 +77,88 @@ func TestIsValidType(t *testing.T) {
 		})
 	}
 }
+
+// TestBuild_Returning tests an INSERT statement with a RETURNING clause.
+func TestBuild_Returning(t *testing.T) {
+	d, _ := dialect.GetDialect("postgres")
+	var c Clause = New(d)
+	c.Set(INSERT, "User", []string{"Name"})
+	c.Set(VALUES, []interface{}{"Tom"})
+	c.Set(RETURNING, []string{"ID"})
+	sql, vars := c.Build(INSERT, VALUES, RETURNING)
+	if sql != `INSERT INTO "User" ("Name") VALUES ($1) RETURNING "ID"` {
+		t.Fatal("failed to build SQL", sql)
+	}
+	if !reflect.DeepEqual(vars, []interface{}{"Tom"}) {
+		t.Fatal("failed to build SQLVars", vars)
+	}
+}
+
+// TestBuild_UpdateExpr tests that expressions are inlined in the UPDATE clause.
+func TestBuild_UpdateExpr(t *testing.T) {
+	var clause Clause
+	clause.Set(UPDATE, "User", map[string]interface{}{"Age": Expr{SQL: "Age + ?", Vars: []interface{}{1}}})
+	clause.Set(WHERE, "Name = ?", "Tom")
+	sql, vars := clause.Build(UPDATE, WHERE)
+	if sql != "UPDATE User SET Age = Age + ? WHERE Name = ?" {
+		t.Fatal("failed to build SQL", sql)
+	}
+	if !reflect.DeepEqual(vars, []interface{}{1, "Tom"}) {
+		t.Fatal("failed to build SQLVars", vars)
+	}
+}
+
+// TestExpand tests that slice variables are expanded into one placeholder per element.
+func TestExpand(t *testing.T) {
+	testCases := []struct {
+		sql, expected string
+		vars, want    []interface{}
+	}{
+		{"ID IN (?) AND Name = ?", "ID IN (?, ?, ?) AND Name = ?", []interface{}{[]int{1, 2, 3}, "Tom"}, []interface{}{1, 2, 3, "Tom"}},
+		{"ID IN (?)", "1 = 0", []interface{}{[]int{}}, nil},
+		{"Name = ? AND \"ID\" NOT IN ( ? )", "Name = ? AND 1 = 1", []interface{}{"Tom", []int{}}, []interface{}{"Tom"}},
+		{"NOT (ID in (?)) OR Age IN (?)", "NOT (1 = 0) OR Age IN (?)", []interface{}{[]int{}, []int{1}}, []interface{}{1}},
+		{"Tags = ?", "Tags = NULL", []interface{}{[]string{}}, nil},
+		{"Code IN (?)", "Code IN (?, ?)", []interface{}{[2]string{"a", "b"}}, []interface{}{"a", "b"}},
+		{"Data = ?", "Data = ?", []interface{}{[]byte("blob")}, []interface{}{[]byte("blob")}},
+		{"Name = '?' AND ID IN (?)", "Name = '?' AND ID IN (?, ?)", []interface{}{[]int{1, 2}}, []interface{}{1, 2}},
+		{"ID IN ($1)", "ID IN ($1)", []interface{}{[]int{1, 2}}, []interface{}{[]int{1, 2}}},
+	}
+	for _, tc := range testCases {
+		sql, vars := Expand(tc.sql, tc.vars)
+		if sql != tc.expected || !reflect.DeepEqual(vars, tc.want) {
+			t.Errorf("got %s %v, want %s %v", sql, vars, tc.expected, tc.want)
+		}
+	}
+}
+
+// TestBuild_Offset tests that the OFFSET clause is rendered with the limit by the dialect.
+func TestBuild_Offset(t *testing.T) {
+	d, _ := dialect.GetDialect("postgres")
+	var c Clause = New(d)
+	c.Set(SELECT, "User", []string{"*"})
+	c.Set(OFFSET, 20, 10)
+	sql, vars := c.Build(SELECT, LIMIT, OFFSET)
+	if sql != `SELECT * FROM "User" LIMIT $1 OFFSET $2` {
+		t.Fatal("failed to build SQL", sql)
+	}
+	if !reflect.DeepEqual(vars, []interface{}{10, 20}) {
+		t.Fatal("failed to build SQLVars", vars)
+	}
+}
+
+// TestBuild_GroupByHaving tests the GROUP BY and HAVING clauses.
+func TestBuild_GroupByHaving(t *testing.T) {
+	d, _ := dialect.GetDialect("postgres")
+	var c Clause = New(d)
+	c.Set(SELECT, "Sale", []string{"Region", "SUM(Amount)"})
+	c.Set(GROUPBY, "Region")
+	c.Set(HAVING, "SUM(Amount) > ?", 10)
+	sql, vars := c.Build(SELECT, WHERE, GROUPBY, HAVING)
+	if sql != `SELECT "Region",SUM(Amount) FROM "Sale" GROUP BY "Region" HAVING SUM(Amount) > $1` {
+		t.Fatal("failed to build SQL", sql)
+	}
+	if !reflect.DeepEqual(vars, []interface{}{10}) {
+		t.Fatal("failed to build SQLVars", vars)
+	}
+}
@@ -0,0 +1,25 @@
+package clause
+
+import "tsorm/dialect"
+
+// Expression is a SQL fragment which builds its own SQL string and variables.
+// Expressions can be used as values in the UPDATE clause.
+type Expression interface {
+	Build(d dialect.Dialect) (string, []interface{})
+}
+
+// Expr is a raw SQL fragment with "?" placeholders for its variables.
+type Expr struct {
+	SQL  string        // SQL is the SQL fragment
+	Vars []interface{} // Vars are the values of the placeholders in SQL
+}
+
+// Build returns the SQL fragment and its variables unchanged.
+func (e Expr) Build(d dialect.Dialect) (string, []interface{}) {
+	return e.SQL, e.Vars
+}
+
+// Quote quotes a column name for the dialect, leaving expressions such as "COUNT(*)" unchanged.
+func Quote(d dialect.Dialect, name string) string {
+	return quote(d, name)
+}
@@ -3,10 +3,13 @@ package clause
 import (
 	"fmt"
 	"strings"
+	"tsorm/dialect"
+	"unicode"
 )
 
 // generator defines the function type for generating SQL statements.
-type generator func(values ...interface{}) (string, []interface{})
+// The dialect is used to quote table and column names and may be nil.
+type generator func(d dialect.Dialect, values ...interface{}) (string, []interface{})
 
 // generators is a map that associates SQL types (Type) with their respective generator functions.
 var generators map[Type]generator
@@ -24,6 +27,10 @@ func init() {
 	generators[UPDATE] = _update
 	generators[DELETE] = _delete
 	generators[COUNT] = _count
+	generators[RETURNING] = _returning
+	generators[OFFSET] = _offset
+	generators[GROUPBY] = _groupby
+	generators[HAVING] = _having
 }
 
 // genBindVars generates the binding variable string, where 'num' specifies the number of binding variables.
@@ -37,17 +44,49 @@ func genBindVars(num int) string {
 	return strings.Join(vars, ", ")
 }
 
+// isIdentifier reports whether name is a plain, possibly dot-qualified, identifier rather than an expression.
+func isIdentifier(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' && r != '.' && r != '$' {
+			return false
+		}
+	}
+	return true
+}
+
+// quote quotes a table or column name for the dialect.
+// Names are left unchanged when no dialect is set or when they are expressions such as "*" or "COUNT(*)".
+func quote(d dialect.Dialect, name interface{}) string {
+	s := fmt.Sprint(name)
+	if d == nil || !isIdentifier(s) {
+		return s
+	}
+	return d.Quote(s)
+}
+
+// quoteAll quotes each of the given names for the dialect.
+func quoteAll(d dialect.Dialect, names []string) []string {
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = quote(d, name)
+	}
+	return quoted
+}
+
 // _insert generates the SQL string and related variables for the INSERT statement.
-func _insert(values ...interface{}) (string, []interface{}) {
+func _insert(d dialect.Dialect, values ...interface{}) (string, []interface{}) {
 	// Parses the input parameters, where tableName represents the table name and fields represents the field names.
-	tableName := values[0]
-	fields := strings.Join(values[1].([]string), ",")
+	tableName := quote(d, values[0])
+	fields := strings.Join(quoteAll(d, values[1].([]string)), ",")
 	// Returns the formatted INSERT statement and an empty variable slice, as variables in the VALUES clause are handled in the _values function.
 	return fmt.Sprintf("INSERT INTO %s (%v)", tableName, fields), []interface{}{}
 }
 
 // _values generates the SQL string and related variables for the VALUES clause.
-func _values(values ...interface{}) (string, []interface{}) {
+func _values(d dialect.Dialect, values ...interface{}) (string, []interface{}) {
 	var bingStr string         // Stores the binding variable string
 	var sql strings.Builder    // Used to build the SQL string
 	var vars []interface{}     // Stores the related variables
@@ -70,44 +109,70 @@ func _values(values ...interface{}) (string, []interface{}) {
 }
 
 // _select generates the SQL string and related variables for the SELECT statement.
-func _select(values ...interface{}) (string, []interface{}) {
+func _select(d dialect.Dialect, values ...interface{}) (string, []interface{}) {
 	// Parses the input parameters, where tableName represents the table name and fields represents the field names.
-	tableName := values[0]
-	fields := strings.Join(values[1].([]string), ",")
+	tableName := quote(d, values[0])
+	fields := strings.Join(quoteAll(d, values[1].([]string)), ",")
 	// Returns the formatted SELECT statement and an empty variable slice, as there are no related variables.
 	return fmt.Sprintf("SELECT %v FROM %s", fields, tableName), []interface{}{}
 }
 
 // _limit generates the SQL string and related variables for the LIMIT clause.
-func _limit(values ...interface{}) (string, []interface{}) {
+func _limit(d dialect.Dialect, values ...interface{}) (string, []interface{}) {
 	// Returns the formatted LIMIT clause and the provided variables, without further processing.
 	return "LIMIT ?", values
 }
 
+// _offset generates the SQL string and related variables for the OFFSET clause, given the offset
+// and optionally the limit of the query. The dialect renders both, so OFFSET replaces LIMIT in the
+// statement; without a dialect or limit, "LIMIT ? OFFSET ?" and "OFFSET ?" are used.
+func _offset(d dialect.Dialect, values ...interface{}) (string, []interface{}) {
+	offset, limit := values[0].(int), -1
+	if len(values) > 1 {
+		limit = values[1].(int)
+	}
+	if d != nil {
+		return d.LimitOffsetSQL(limit, offset)
+	}
+	if limit >= 0 {
+		return "LIMIT ? OFFSET ?", []interface{}{limit, offset}
+	}
+	return "OFFSET ?", []interface{}{offset}
+}
+
 // _where generates the SQL string and related variables for the WHERE clause.
-func _where(values ...interface{}) (string, []interface{}) {
+func _where(d dialect.Dialect, values ...interface{}) (string, []interface{}) {
 	// Parses the input parameters, where desc represents the WHERE condition description and vars represents the variables in the WHERE condition.
 	desc, vars := values[0], values[1:]
+	// Slice variables are expanded into one placeholder per element.
+	sql, vars := Expand(fmt.Sprint(desc), vars)
 	// Returns the formatted WHERE clause and the related variable slice.
-	return fmt.Sprintf("WHERE %s", desc), vars
+	return fmt.Sprintf("WHERE %s", sql), vars
 }
 
 // _orderby generates the SQL string and related variables for the ORDER BY clause.
-func _orderby(values ...interface{}) (string, []interface{}) {
+func _orderby(d dialect.Dialect, values ...interface{}) (string, []interface{}) {
 	// Returns the formatted ORDER BY clause and an empty variable slice, as there are no related variables.
 	return fmt.Sprintf("ORDER BY %s", values[0]), []interface{}{}
 }
 
 // _update generates the SQL string and related variables for the UPDATE statement.
-func _update(values ...interface{}) (string, []interface{}) {
+func _update(d dialect.Dialect, values ...interface{}) (string, []interface{}) {
 	// Parses the input parameters, where tableName represents the table name and fieldNames represents the field names and their corresponding values.
-	tableName := values[0]
+	tableName := quote(d, values[0])
 	fieldNames := values[1].(map[string]interface{})
 	var keys []string      // Stores the strings of field names and values
 	var vars []interface{} // Stores the related variables
 	// Iterates over the field names and values, building the SET clause.
 	for k, v := range fieldNames {
-		keys = append(keys, k+" = ?")
+		// Expressions such as "version + 1" are inlined with their own variables.
+		if expr, ok := v.(Expression); ok {
+			sql, exprVars := expr.Build(d)
+			keys = append(keys, quote(d, k)+" = "+sql)
+			vars = append(vars, exprVars...)
+			continue
+		}
+		keys = append(keys, quote(d, k)+" = ?")
 		vars = append(vars, v) // Adds the value to the variable slice
 	}
 	// Returns the formatted UPDATE statement and related variable slice.
@@ -115,13 +180,35 @@ func _update(values ...interface{}) (string, []interface{}) {
 }
 
 // _delete generates the SQL string and related variables for the DELETE statement.
-func _delete(values ...interface{}) (string, []interface{}) {
+func _delete(d dialect.Dialect, values ...interface{}) (string, []interface{}) {
 	// Returns the formatted DELETE statement and provided variables, without further processing.
-	return fmt.Sprintf("DELETE FROM %s", values[0]), []interface{}{}
+	return fmt.Sprintf("DELETE FROM %s", quote(d, values[0])), []interface{}{}
+}
+
+// _groupby generates the SQL string and related variables for the GROUP BY clause from the given column names.
+func _groupby(d dialect.Dialect, values ...interface{}) (string, []interface{}) {
+	columns := make([]string, len(values))
+	for i, column := range values {
+		columns[i] = quote(d, column)
+	}
+	return fmt.Sprintf("GROUP BY %s", strings.Join(columns, ", ")), []interface{}{}
+}
+
+// _having generates the SQL string and related variables for the HAVING clause.
+func _having(d dialect.Dialect, values ...interface{}) (string, []interface{}) {
+	// Slice variables are expanded into one placeholder per element, as in WHERE.
+	sql, vars := Expand(fmt.Sprint(values[0]), values[1:])
+	return fmt.Sprintf("HAVING %s", sql), vars
 }
 
 // _count generates the SQL string and related variables for the COUNT function.
-func _count(values ...interface{}) (string, []interface{}) {
+func _count(d dialect.Dialect, values ...interface{}) (string, []interface{}) {
 	// Calls the _select function to generate the SELECT COUNT(*) statement and returns.
-	return _select(values[0], []string{"COUNT(*)"})
+	return _select(d, values[0], []string{"COUNT(*)"})
+}
+
+// _returning generates the SQL string and related variables for the RETURNING clause.
+func _returning(d dialect.Dialect, values ...interface{}) (string, []interface{}) {
+	// Returns the RETURNING clause listing the quoted column names, without variables.
+	return fmt.Sprintf("RETURNING %s", strings.Join(quoteAll(d, values[0].([]string)), ",")), []interface{}{}
 }
@@ -1,7 +1,12 @@
 package dialect
 
 import (
+	"context"
+	"database/sql"
+	"fmt"
 	"reflect"
+	"sort"
+	"strings"
 )
 
 // Dialect represents an interface for defining SQL dialects.
@@ -9,8 +14,68 @@ type Dialect interface {
 	// DataTypeOf returns the SQL data type corresponding to the given Go type.
 	DataTypeOf(t reflect.Value) string
 
+	// SizedDataTypeOf returns the SQL data type for the given Go type in a column declared with the given size.
+	SizedDataTypeOf(t reflect.Value, size int) string
+
+	// AutoIncrementSQL returns the definition, following the column name, of an auto-increment
+	// column whose data type is dataType. primaryKey reports whether the column is the only primary key
+	// of the table, declared inline, rather than part of a composite one.
+	AutoIncrementSQL(dataType string, primaryKey bool) string
+
+	// CompositeAutoIncrement reports whether an auto-increment column can be part of a composite primary key.
+	CompositeAutoIncrement() bool
+
+	// DropColumnInPlace reports whether columns are dropped with ALTER TABLE ... DROP COLUMN,
+	// keeping keys, indexes and constraints, rather than by rebuilding the table without them.
+	DropColumnInPlace() bool
+
+	// UseReturning reports whether the key generated by a single-row INSERT is read from
+	// a RETURNING clause rather than from LastInsertId.
+	UseReturning() bool
+
+	// LimitOffsetSQL returns the clauses which limit a query to limit rows after skipping offset rows,
+	// with "?" placeholders for their variables. A negative limit means no limit.
+	LimitOffsetSQL(limit, offset int) (string, []interface{})
+
 	// TableExistSQL returns the SQL query to check if a table exists, along with any associated variables.
 	TableExistSQL(tableName string) (string, []interface{})
+
+	// BindVar returns the placeholder for the index-th (1-based) bind variable of a statement.
+	BindVar(index int) string
+
+	// Quote returns the identifier quoted for the dialect, e.g. "order" or `order`.
+	Quote(identifier string) string
+
+	// Columns returns the columns of an existing table, in table order.
+	Columns(ctx context.Context, q Queryer, tableName string) ([]ColumnInfo, error)
+
+	// Indexes returns the indexes of an existing table with their columns.
+	Indexes(ctx context.Context, q Queryer, tableName string) ([]IndexInfo, error)
+
+	// RegisterType maps values of the Go type typ to the SQL type sqlType in DataTypeOf.
+	RegisterType(typ reflect.Type, sqlType string)
+}
+
+// Queryer runs the introspection queries of a dialect. It is satisfied by *sql.DB and *sql.Tx.
+type Queryer interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+}
+
+// ColumnInfo describes a column of an existing table.
+type ColumnInfo struct {
+	Name       string  // Name of the column
+	Type       string  // Type is the SQL type declared for the column
+	NotNull    bool    // NotNull reports whether the column rejects NULL
+	Default    *string // Default is the default value expression, nil if there is none
+	PrimaryKey bool    // PrimaryKey reports whether the column is part of the primary key
+}
+
+// IndexInfo describes an index of an existing table.
+type IndexInfo struct {
+	Name    string   // Name of the index
+	Unique  bool     // Unique reports whether the index enforces uniqueness
+	Primary bool     // Primary reports whether the index backs the primary key
+	Columns []string // Columns are the indexed columns, in index order
 }
 
 // dialectsMap is a map that stores registered dialects.
@@ -27,3 +92,85 @@ func GetDialect(name string) (dialect Dialect, ok bool) {
 	dialect, ok = dialectsMap[name]
 	return
 }
+
+// NameOf returns the name under which d is registered, or its Go type if it is not registered.
+// If d is registered under several names, the first in sorted order is returned.
+func NameOf(d Dialect) string {
+	var names []string
+	for name, dialect := range dialectsMap {
+		if dialect == d {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return fmt.Sprintf("%T", d)
+	}
+	sort.Strings(names)
+	return names[0]
+}
+
+// limitOffsetSQL returns "LIMIT ? OFFSET ?" with the given limit and offset, leaving out the
+// clauses which are not set. noLimit is the LIMIT value used for an offset without a limit,
+// for dialects which cannot have OFFSET without LIMIT, or "" if OFFSET can stand alone.
+func limitOffsetSQL(limit, offset int, noLimit string) (string, []interface{}) {
+	var parts []string
+	var vars []interface{}
+	if limit >= 0 {
+		parts = append(parts, "LIMIT ?")
+		vars = append(vars, limit)
+	} else if offset > 0 && noLimit != "" {
+		parts = append(parts, "LIMIT "+noLimit)
+	}
+	if offset > 0 {
+		parts = append(parts, "OFFSET ?")
+		vars = append(vars, offset)
+	}
+	return strings.Join(parts, " "), vars
+}
+
+// quoteIdentifier wraps every dot-separated part of identifier in the quote character q,
+// doubling any q inside a part. "*" and parts that are already quoted are left unchanged.
+func quoteIdentifier(identifier string, q string) string {
+	parts := strings.Split(identifier, ".")
+	for i, part := range parts {
+		if part == "*" || (len(part) > 1 && strings.HasPrefix(part, q) && strings.HasSuffix(part, q)) {
+			continue
+		}
+		parts[i] = q + strings.ReplaceAll(part, q, q+q) + q
+	}
+	return strings.Join(parts, ".")
+}
+
+// scanColumns reads ColumnInfo rows made of the name, type, not null, default and primary key columns.
+func scanColumns(rows *sql.Rows) (columns []ColumnInfo, err error) {
+	defer rows.Close()
+	for rows.Next() {
+		var c ColumnInfo
+		if err = rows.Scan(&c.Name, &c.Type, &c.NotNull, &c.Default, &c.PrimaryKey); err != nil {
+			return nil, err
+		}
+		columns = append(columns, c)
+	}
+	return columns, rows.Err()
+}
+
+// scanIndexes reads rows made of the index name, unique, primary and column name columns,
+// ordered by index, and groups the columns of each index.
+func scanIndexes(rows *sql.Rows) (indexes []IndexInfo, err error) {
+	defer rows.Close()
+	for rows.Next() {
+		var index IndexInfo
+		var column string
+		if err = rows.Scan(&index.Name, &index.Unique, &index.Primary, &column); err != nil {
+			return nil, err
+		}
+		// Append the column to the current index, or start a new one.
+		if n := len(indexes); n > 0 && indexes[n-1].Name == index.Name {
+			indexes[n-1].Columns = append(indexes[n-1].Columns, column)
+			continue
+		}
+		index.Columns = []string{column}
+		indexes = append(indexes, index)
+	}
+	return indexes, rows.Err()
+}
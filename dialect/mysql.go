@@ -0,0 +1,167 @@
+package dialect
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// mysql represents the MySQL dialect.
+type mysql struct {
+	typeRegistry // typeRegistry holds the types registered on the dialect
+}
+
+// Ensure that mysql implements the Dialect interface.
+var _ Dialect = (*mysql)(nil)
+
+// mysqlDefaultStringSize is the VARCHAR length used for strings without a declared size.
+const mysqlDefaultStringSize = 255
+
+// mysqlMaxVarcharSize is the largest VARCHAR length usable with the utf8mb4 charset.
+const mysqlMaxVarcharSize = 16383
+
+// mysqlMaxVarbinarySize is the largest VARBINARY length.
+const mysqlMaxVarbinarySize = 65535
+
+// DataTypeOf returns the corresponding SQL data type for the given Go type.
+func (m *mysql) DataTypeOf(t reflect.Value) string {
+	// Registered types, driver.Valuer and sql.Scanner implementations take precedence over the kind.
+	if sqlType, ok := m.customDataTypeOf(m, t); ok {
+		return sqlType
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return "TINYINT(1)"
+	case reflect.Int8:
+		return "TINYINT"
+	case reflect.Int16:
+		return "SMALLINT"
+	case reflect.Int32:
+		return "INT"
+	case reflect.Int, reflect.Int64:
+		return "BIGINT"
+	case reflect.Uint8:
+		return "TINYINT UNSIGNED"
+	case reflect.Uint16:
+		return "SMALLINT UNSIGNED"
+	case reflect.Uint32:
+		return "INT UNSIGNED"
+	case reflect.Uint, reflect.Uint64:
+		return "BIGINT UNSIGNED"
+	case reflect.Float32:
+		return "FLOAT"
+	case reflect.Float64:
+		return "DOUBLE"
+	case reflect.String:
+		return m.stringType(mysqlDefaultStringSize)
+	case reflect.Array:
+		// Fixed-size byte arrays, such as UUIDs, are stored as BINARY of the same length.
+		if t.Type().Elem().Kind() == reflect.Uint8 {
+			return fmt.Sprintf("BINARY(%d)", t.Len())
+		}
+		return "LONGBLOB"
+	case reflect.Slice:
+		return "LONGBLOB"
+	case reflect.Struct:
+		// Check if the struct type is time.Time, if so, return "DATETIME(6)".
+		if _, ok := t.Interface().(time.Time); ok {
+			return "DATETIME(6)"
+		}
+	}
+	// Panic if the type is not supported.
+	panic(fmt.Sprintf("invalid SQL type %s (%s)", t.Type().Name(), t.Kind()))
+}
+
+// SizedDataTypeOf returns VARCHAR(size) or LONGTEXT for strings, VARBINARY(size) or LONGBLOB
+// for byte slices and the type given by DataTypeOf otherwise.
+func (m *mysql) SizedDataTypeOf(t reflect.Value, size int) string {
+	if _, ok := m.customDataTypeOf(m, t); !ok {
+		switch {
+		case t.Kind() == reflect.String:
+			return m.stringType(size)
+		case t.Kind() == reflect.Slice && t.Type().Elem().Kind() == reflect.Uint8 && size <= mysqlMaxVarbinarySize:
+			return fmt.Sprintf("VARBINARY(%d)", size)
+		}
+	}
+	return m.DataTypeOf(t)
+}
+
+// AutoIncrementSQL returns the definition of an AUTO_INCREMENT column.
+func (m *mysql) AutoIncrementSQL(dataType string, primaryKey bool) string {
+	if !primaryKey {
+		return dataType + " AUTO_INCREMENT"
+	}
+	return dataType + " AUTO_INCREMENT PRIMARY KEY"
+}
+
+// stringType returns VARCHAR(size) for sizes MySQL can index, LONGTEXT otherwise.
+func (m *mysql) stringType(size int) string {
+	if size > mysqlMaxVarcharSize {
+		return "LONGTEXT"
+	}
+	return fmt.Sprintf("VARCHAR(%d)", size)
+}
+
+// CompositeAutoIncrement always reports true; InnoDB accepts an AUTO_INCREMENT column in a composite key.
+func (m *mysql) CompositeAutoIncrement() bool {
+	return true
+}
+
+// DropColumnInPlace always reports true.
+func (m *mysql) DropColumnInPlace() bool {
+	return true
+}
+
+// UseReturning always reports false, as MySQL has no RETURNING clause.
+func (m *mysql) UseReturning() bool {
+	return false
+}
+
+// LimitOffsetSQL returns "LIMIT ? OFFSET ?"; MySQL requires a LIMIT before OFFSET, so the largest
+// unsigned BIGINT is used when there is no limit.
+func (m *mysql) LimitOffsetSQL(limit, offset int) (string, []interface{}) {
+	return limitOffsetSQL(limit, offset, "18446744073709551615")
+}
+
+// TableExistSQL returns the SQL query to check if a table exists, along with any associated variables.
+func (m *mysql) TableExistSQL(tableName string) (string, []interface{}) {
+	args := []interface{}{tableName}
+	// SQL query to check if the table exists in the current database.
+	return "SELECT table_name FROM information_schema.tables WHERE table_schema = DATABASE() AND table_name = ?", args
+}
+
+// BindVar returns the placeholder for the index-th bind variable, which is always "?" in MySQL.
+func (m *mysql) BindVar(index int) string {
+	return "?"
+}
+
+// Quote returns the identifier wrapped in backticks.
+func (m *mysql) Quote(identifier string) string {
+	return quoteIdentifier(identifier, "`")
+}
+
+// Columns returns the columns of an existing table using information_schema.
+func (m *mysql) Columns(ctx context.Context, q Queryer, tableName string) ([]ColumnInfo, error) {
+	rows, err := q.QueryContext(ctx, "SELECT column_name, column_type, is_nullable = 'NO', column_default, column_key = 'PRI' "+
+		"FROM information_schema.columns WHERE table_schema = DATABASE() AND table_name = ? ORDER BY ordinal_position", tableName)
+	if err != nil {
+		return nil, err
+	}
+	return scanColumns(rows)
+}
+
+// Indexes returns the indexes of an existing table using information_schema.
+func (m *mysql) Indexes(ctx context.Context, q Queryer, tableName string) ([]IndexInfo, error) {
+	rows, err := q.QueryContext(ctx, "SELECT index_name, non_unique = 0, index_name = 'PRIMARY', column_name "+
+		"FROM information_schema.statistics WHERE table_schema = DATABASE() AND table_name = ? ORDER BY index_name, seq_in_index", tableName)
+	if err != nil {
+		return nil, err
+	}
+	return scanIndexes(rows)
+}
+
+// init registers the mysql dialect when the package is initialized.
+func init() {
+	RegisterDialect("mysql", &mysql{})
+}
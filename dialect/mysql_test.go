@@ -0,0 +1,82 @@
+package dialect
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+// TestMySQLDataTypeOf tests the DataTypeOf method of the mysql dialect.
+func TestMySQLDataTypeOf(t *testing.T) {
+	// Create a new instance of the mysql dialect.
+	mysql := &mysql{}
+
+	// Test cases with different Go types.
+	testCases := []struct {
+		input    interface{}
+		expected string
+	}{
+		{true, "TINYINT(1)"},
+		{int8(1), "TINYINT"},
+		{int16(1), "SMALLINT"},
+		{int32(1), "INT"},
+		{int(1), "BIGINT"},
+		{int64(1), "BIGINT"},
+		{uint8(1), "TINYINT UNSIGNED"},
+		{uint16(1), "SMALLINT UNSIGNED"},
+		{uint32(1), "INT UNSIGNED"},
+		{uint64(1), "BIGINT UNSIGNED"},
+		{float32(1.0), "FLOAT"},
+		{float64(1.0), "DOUBLE"},
+		{"text", "VARCHAR(255)"},
+		{[]byte{1, 2, 3}, "LONGBLOB"},
+		{[16]byte{}, "BINARY(16)"},
+		{time.Now(), "DATETIME(6)"},
+	}
+
+	// Iterate over test cases.
+	for _, tc := range testCases {
+		t.Run(reflect.TypeOf(tc.input).String(), func(t *testing.T) {
+			if dataType := mysql.DataTypeOf(reflect.ValueOf(tc.input)); dataType != tc.expected {
+				t.Errorf("got %s, want %s", dataType, tc.expected)
+			}
+		})
+	}
+
+	// Strings too long for VARCHAR fall back to LONGTEXT.
+	if got := mysql.stringType(mysqlMaxVarcharSize + 1); got != "LONGTEXT" {
+		t.Errorf("got %s, want LONGTEXT", got)
+	}
+}
+
+// TestMySQLTableExistSQL tests the TableExistSQL method of the mysql dialect.
+func TestMySQLTableExistSQL(t *testing.T) {
+	mysql := &mysql{}
+	sql, vars := mysql.TableExistSQL("users")
+
+	expectedSQL := "SELECT table_name FROM information_schema.tables WHERE table_schema = DATABASE() AND table_name = ?"
+	if sql != expectedSQL {
+		t.Errorf("got %s, want %s", sql, expectedSQL)
+	}
+	if !reflect.DeepEqual(vars, []interface{}{"users"}) {
+		t.Errorf("got %v, want %v", vars, []interface{}{"users"})
+	}
+}
+
+// TestMySQLQuote tests the identifier quoting of the mysql dialect.
+func TestMySQLQuote(t *testing.T) {
+	mysql := &mysql{}
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{"group", "`group`"},
+		{"we`ird", "`we``ird`"},
+	}
+
+	for _, tc := range testCases {
+		if got := mysql.Quote(tc.input); got != tc.expected {
+			t.Errorf("Quote(%s) got %s, want %s", tc.input, got, tc.expected)
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package dialect
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// postgres represents the PostgreSQL dialect.
+type postgres struct {
+	typeRegistry // typeRegistry holds the types registered on the dialect
+}
+
+// Ensure that postgres implements the Dialect interface.
+var _ Dialect = (*postgres)(nil)
+
+// DataTypeOf returns the corresponding SQL data type for the given Go type.
+func (p *postgres) DataTypeOf(t reflect.Value) string {
+	// Registered types, driver.Valuer and sql.Scanner implementations take precedence over the kind.
+	if sqlType, ok := p.customDataTypeOf(p, t); ok {
+		return sqlType
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int8, reflect.Int16, reflect.Uint8:
+		return "smallint"
+	case reflect.Int, reflect.Int32, reflect.Uint16:
+		return "integer"
+	case reflect.Int64, reflect.Uint, reflect.Uint32, reflect.Uint64:
+		return "bigint"
+	case reflect.Float32:
+		return "real"
+	case reflect.Float64:
+		return "double precision"
+	case reflect.String:
+		return "text"
+	case reflect.Array, reflect.Slice:
+		return "bytea"
+	case reflect.Struct:
+		// Check if the struct type is time.Time, if so, return "timestamptz".
+		if _, ok := t.Interface().(time.Time); ok {
+			return "timestamptz"
+		}
+	}
+	// Panic if the type is not supported.
+	panic(fmt.Sprintf("invalid SQL type %s (%s)", t.Type().Name(), t.Kind()))
+}
+
+// SizedDataTypeOf returns varchar(size) for strings and the type given by DataTypeOf otherwise.
+func (p *postgres) SizedDataTypeOf(t reflect.Value, size int) string {
+	if _, ok := p.customDataTypeOf(p, t); !ok && t.Kind() == reflect.String {
+		return fmt.Sprintf("varchar(%d)", size)
+	}
+	return p.DataTypeOf(t)
+}
+
+// AutoIncrementSQL returns the definition of an identity column.
+func (p *postgres) AutoIncrementSQL(dataType string, primaryKey bool) string {
+	if !primaryKey {
+		return dataType + " GENERATED BY DEFAULT AS IDENTITY"
+	}
+	return dataType + " GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY"
+}
+
+// CompositeAutoIncrement always reports true, as identity columns can be part of any key.
+func (p *postgres) CompositeAutoIncrement() bool {
+	return true
+}
+
+// DropColumnInPlace always reports true.
+func (p *postgres) DropColumnInPlace() bool {
+	return true
+}
+
+// UseReturning always reports true, as PostgreSQL drivers do not support LastInsertId.
+func (p *postgres) UseReturning() bool {
+	return true
+}
+
+// LimitOffsetSQL returns "LIMIT ? OFFSET ?"; PostgreSQL accepts OFFSET without LIMIT.
+func (p *postgres) LimitOffsetSQL(limit, offset int) (string, []interface{}) {
+	return limitOffsetSQL(limit, offset, "")
+}
+
+// TableExistSQL returns the SQL query to check if a table exists, along with any associated variables.
+func (p *postgres) TableExistSQL(tableName string) (string, []interface{}) {
+	args := []interface{}{tableName}
+	// SQL query to check if the table exists in the current schema.
+	return "SELECT table_name FROM information_schema.tables WHERE table_schema = current_schema() AND table_name = $1", args
+}
+
+// BindVar returns the numbered placeholder "$index" used by PostgreSQL.
+func (p *postgres) BindVar(index int) string {
+	return "$" + strconv.Itoa(index)
+}
+
+// Quote returns the identifier wrapped in double quotes.
+func (p *postgres) Quote(identifier string) string {
+	return quoteIdentifier(identifier, `"`)
+}
+
+// Columns returns the columns of an existing table using information_schema.
+func (p *postgres) Columns(ctx context.Context, q Queryer, tableName string) ([]ColumnInfo, error) {
+	rows, err := q.QueryContext(ctx, `SELECT c.column_name, c.data_type, c.is_nullable = 'NO', c.column_default,
+	EXISTS (SELECT 1 FROM information_schema.table_constraints tc
+		JOIN information_schema.key_column_usage k
+		ON k.constraint_name = tc.constraint_name AND k.table_schema = tc.table_schema
+		WHERE tc.constraint_type = 'PRIMARY KEY' AND tc.table_schema = c.table_schema
+		AND tc.table_name = c.table_name AND k.column_name = c.column_name)
+FROM information_schema.columns c
+WHERE c.table_schema = current_schema() AND c.table_name = $1
+ORDER BY c.ordinal_position`, tableName)
+	if err != nil {
+		return nil, err
+	}
+	return scanColumns(rows)
+}
+
+// Indexes returns the indexes of an existing table using the pg_index catalog.
+func (p *postgres) Indexes(ctx context.Context, q Queryer, tableName string) ([]IndexInfo, error) {
+	rows, err := q.QueryContext(ctx, `SELECT i.relname, ix.indisunique, ix.indisprimary, a.attname
+FROM pg_class t
+JOIN pg_index ix ON ix.indrelid = t.oid
+JOIN pg_class i ON i.oid = ix.indexrelid
+JOIN pg_attribute a ON a.attrelid = t.oid AND a.attnum = ANY(ix.indkey)
+WHERE t.relname = $1 AND t.relnamespace = current_schema()::regnamespace
+ORDER BY i.relname, array_position(ix.indkey::int2[], a.attnum)`, tableName)
+	if err != nil {
+		return nil, err
+	}
+	return scanIndexes(rows)
+}
+
+// init registers the postgres dialect when the package is initialized.
+func init() {
+	RegisterDialect("postgres", &postgres{})
+}
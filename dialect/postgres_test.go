@@ -0,0 +1,77 @@
+package dialect
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+// TestPostgresDataTypeOf tests the DataTypeOf method of the postgres dialect.
+func TestPostgresDataTypeOf(t *testing.T) {
+	// Create a new instance of the postgres dialect.
+	postgres := &postgres{}
+
+	// Test cases with different Go types.
+	testCases := []struct {
+		input    interface{}
+		expected string
+	}{
+		{true, "boolean"},
+		{int8(1), "smallint"},
+		{int16(1), "smallint"},
+		{int(1), "integer"},
+		{int32(1), "integer"},
+		{int64(1), "bigint"},
+		{uint8(1), "smallint"},
+		{uint16(1), "integer"},
+		{uint32(1), "bigint"},
+		{uint64(1), "bigint"},
+		{float32(1.0), "real"},
+		{float64(1.0), "double precision"},
+		{"text", "text"},
+		{[]byte{1, 2, 3}, "bytea"},
+		{time.Now(), "timestamptz"},
+	}
+
+	// Iterate over test cases.
+	for _, tc := range testCases {
+		t.Run(reflect.TypeOf(tc.input).Name(), func(t *testing.T) {
+			if dataType := postgres.DataTypeOf(reflect.ValueOf(tc.input)); dataType != tc.expected {
+				t.Errorf("got %s, want %s", dataType, tc.expected)
+			}
+		})
+	}
+}
+
+// TestPostgresTableExistSQL tests the TableExistSQL method of the postgres dialect.
+func TestPostgresTableExistSQL(t *testing.T) {
+	postgres := &postgres{}
+	sql, vars := postgres.TableExistSQL("users")
+
+	expectedSQL := "SELECT table_name FROM information_schema.tables WHERE table_schema = current_schema() AND table_name = $1"
+	if sql != expectedSQL {
+		t.Errorf("got %s, want %s", sql, expectedSQL)
+	}
+	if !reflect.DeepEqual(vars, []interface{}{"users"}) {
+		t.Errorf("got %v, want %v", vars, []interface{}{"users"})
+	}
+}
+
+// TestPostgresBindVar tests the numbered placeholders of the postgres dialect.
+func TestPostgresBindVar(t *testing.T) {
+	postgres := &postgres{}
+	if got := postgres.BindVar(1); got != "$1" {
+		t.Errorf("got %s, want $1", got)
+	}
+	if got := postgres.BindVar(12); got != "$12" {
+		t.Errorf("got %s, want $12", got)
+	}
+}
+
+// TestPostgresQuote tests the identifier quoting of the postgres dialect.
+func TestPostgresQuote(t *testing.T) {
+	postgres := &postgres{}
+	if got := postgres.Quote(`"group"`); got != `"group"` {
+		t.Errorf("got %s, want %s", got, `"group"`)
+	}
+}
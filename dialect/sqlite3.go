@@ -1,19 +1,26 @@
 package dialect
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 	"time"
 )
 
 // sqlite3 represents the SQLite3 dialect.
-type sqlite3 struct{}
+type sqlite3 struct {
+	typeRegistry // typeRegistry holds the types registered on the dialect
+}
 
 // Ensure that sqlite3 implements the Dialect interface.
 var _ Dialect = (*sqlite3)(nil)
 
 // DataTypeOf returns the corresponding SQL data type for the given Go type.
 func (s *sqlite3) DataTypeOf(t reflect.Value) string {
+	// Registered types, driver.Valuer and sql.Scanner implementations take precedence over the kind.
+	if sqlType, ok := s.customDataTypeOf(s, t); ok {
+		return sqlType
+	}
 	switch t.Kind() {
 	case reflect.Bool:
 		return "bool"
@@ -38,6 +45,37 @@ func (s *sqlite3) DataTypeOf(t reflect.Value) string {
 	panic(fmt.Sprintf("invalid SQL type %s (%s)", t.Type().Name(), t.Kind()))
 }
 
+// SizedDataTypeOf returns the same type as DataTypeOf, as SQLite3 does not enforce column sizes.
+func (s *sqlite3) SizedDataTypeOf(t reflect.Value, size int) string {
+	return s.DataTypeOf(t)
+}
+
+// AutoIncrementSQL returns the definition of a rowid alias column, which must be declared "integer".
+// It is always the only primary key, see CompositeAutoIncrement.
+func (s *sqlite3) AutoIncrementSQL(dataType string, primaryKey bool) string {
+	return "integer PRIMARY KEY AUTOINCREMENT"
+}
+
+// CompositeAutoIncrement always reports false, as SQLite only generates the keys of rowid alias columns.
+func (s *sqlite3) CompositeAutoIncrement() bool {
+	return false
+}
+
+// DropColumnInPlace always reports false, as SQLite only drops columns which no key, index or constraint uses.
+func (s *sqlite3) DropColumnInPlace() bool {
+	return false
+}
+
+// UseReturning always reports false, as LastInsertId reports the key of single-row inserts.
+func (s *sqlite3) UseReturning() bool {
+	return false
+}
+
+// LimitOffsetSQL returns "LIMIT ? OFFSET ?"; SQLite requires a LIMIT before OFFSET, -1 meaning no limit.
+func (s *sqlite3) LimitOffsetSQL(limit, offset int) (string, []interface{}) {
+	return limitOffsetSQL(limit, offset, "-1")
+}
+
 // TableExistSQL returns the SQL query to check if a table exists, along with any associated variables.
 func (s *sqlite3) TableExistSQL(tableName string) (string, []interface{}) {
 	args := []interface{}{tableName}
@@ -45,6 +83,35 @@ func (s *sqlite3) TableExistSQL(tableName string) (string, []interface{}) {
 	return "SELECT name FROM sqlite_master WHERE type='table' and name = ?", args
 }
 
+// BindVar returns the placeholder for the index-th bind variable, which is always "?" in SQLite3.
+func (s *sqlite3) BindVar(index int) string {
+	return "?"
+}
+
+// Quote returns the identifier wrapped in double quotes.
+func (s *sqlite3) Quote(identifier string) string {
+	return quoteIdentifier(identifier, `"`)
+}
+
+// Columns returns the columns of an existing table using PRAGMA table_info.
+func (s *sqlite3) Columns(ctx context.Context, q Queryer, tableName string) ([]ColumnInfo, error) {
+	rows, err := q.QueryContext(ctx, "SELECT name, type, \"notnull\", dflt_value, pk > 0 FROM pragma_table_info(?) ORDER BY cid", tableName)
+	if err != nil {
+		return nil, err
+	}
+	return scanColumns(rows)
+}
+
+// Indexes returns the indexes of an existing table using PRAGMA index_list and index_info.
+func (s *sqlite3) Indexes(ctx context.Context, q Queryer, tableName string) ([]IndexInfo, error) {
+	rows, err := q.QueryContext(ctx, "SELECT il.name, il.\"unique\", il.origin = 'pk', ii.name "+
+		"FROM pragma_index_list(?) AS il, pragma_index_info(il.name) AS ii ORDER BY il.seq, ii.seqno", tableName)
+	if err != nil {
+		return nil, err
+	}
+	return scanIndexes(rows)
+}
+
 // init registers the sqlite3 dialect when the package is initialized.
 func init() {
 	RegisterDialect("sqlite3", &sqlite3{})
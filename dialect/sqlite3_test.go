@@ -1,6 +1,10 @@
 package dialect
 
 import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
 	"reflect"
 	"testing"
 	"time"
@@ -71,3 +75,118 @@ func TestSQLite3TableExistSQL(t *testing.T) {
 		t.Errorf("got %v, want %v", vars, expectedVars)
 	}
 }
+
+// TestSQLite3Quote tests the identifier quoting of the sqlite3 dialect.
+func TestSQLite3Quote(t *testing.T) {
+	sqlite3 := &sqlite3{}
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{"order", `"order"`},
+		{`we"ird`, `"we""ird"`},
+		{"user.name", `"user"."name"`},
+		{"user.*", `"user".*`},
+	}
+
+	for _, tc := range testCases {
+		if got := sqlite3.Quote(tc.input); got != tc.expected {
+			t.Errorf("Quote(%s) got %s, want %s", tc.input, got, tc.expected)
+		}
+	}
+}
+
+// Money is a custom type stored as its amount of cents.
+type Money struct {
+	cents int64
+}
+
+// Value implements driver.Valuer.
+func (m Money) Value() (driver.Value, error) {
+	return m.cents, nil
+}
+
+// Attrs is a custom map type stored as JSON text.
+type Attrs map[string]string
+
+// Value implements driver.Valuer.
+func (a Attrs) Value() (driver.Value, error) {
+	if a == nil {
+		return nil, nil
+	}
+	return json.Marshal(a)
+}
+
+// Scan implements sql.Scanner.
+func (a *Attrs) Scan(src interface{}) error {
+	data, ok := src.([]byte)
+	if !ok {
+		return fmt.Errorf("cannot scan %T into Attrs", src)
+	}
+	return json.Unmarshal(data, a)
+}
+
+// Level is a custom integer type stored as its name.
+type Level int
+
+// Value implements driver.Valuer.
+func (l Level) Value() (driver.Value, error) {
+	return fmt.Sprintf("level-%d", int(l)), nil
+}
+
+// Point is a custom type without any SQL conversion.
+type Point struct {
+	X, Y float64
+}
+
+// TestSQLite3CustomTypes tests DataTypeOf with driver.Valuer, sql.Scanner and registered types.
+func TestSQLite3CustomTypes(t *testing.T) {
+	sqlite := &sqlite3{}
+	sqlite.RegisterType(reflect.TypeOf(Point{}), "point")
+
+	testCases := []struct {
+		input    interface{}
+		expected string
+	}{
+		{sql.NullString{}, "text"},
+		{sql.NullInt64{}, "bigint"},
+		{sql.NullBool{}, "bool"},
+		{sql.NullTime{}, "datetime"},
+		{Money{}, "bigint"},
+		{Attrs{}, "blob"},
+		{Attrs(nil), "text"},
+		{Level(0), "text"},
+		{Point{}, "point"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(reflect.TypeOf(tc.input).Name(), func(t *testing.T) {
+			if dataType := sqlite.DataTypeOf(reflect.ValueOf(tc.input)); dataType != tc.expected {
+				t.Errorf("got %s, want %s", dataType, tc.expected)
+			}
+		})
+	}
+}
+
+// TestLimitOffsetSQL tests the pagination clauses of every dialect.
+func TestLimitOffsetSQL(t *testing.T) {
+	testCases := []struct {
+		dialect       Dialect
+		limit, offset int
+		expected      string
+		vars          []interface{}
+	}{
+		{&sqlite3{}, 10, 0, "LIMIT ?", []interface{}{10}},
+		{&sqlite3{}, 10, 20, "LIMIT ? OFFSET ?", []interface{}{10, 20}},
+		{&sqlite3{}, -1, 20, "LIMIT -1 OFFSET ?", []interface{}{20}},
+		{&mysql{}, -1, 20, "LIMIT 18446744073709551615 OFFSET ?", []interface{}{20}},
+		{&postgres{}, -1, 20, "OFFSET ?", []interface{}{20}},
+		{&postgres{}, 5, 20, "LIMIT ? OFFSET ?", []interface{}{5, 20}},
+	}
+	for _, tc := range testCases {
+		sql, vars := tc.dialect.LimitOffsetSQL(tc.limit, tc.offset)
+		if sql != tc.expected || !reflect.DeepEqual(vars, tc.vars) {
+			t.Errorf("%T: got %s %v, want %s %v", tc.dialect, sql, vars, tc.expected, tc.vars)
+		}
+	}
+}
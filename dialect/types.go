@@ -0,0 +1,94 @@
+package dialect
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// valuerType and scannerType are the interfaces of types that convert themselves to and from SQL values.
+var (
+	valuerType  = reflect.TypeOf((*driver.Valuer)(nil)).Elem()
+	scannerType = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+	timeType    = reflect.TypeOf(time.Time{})
+)
+
+// typeRegistry holds the SQL types registered for Go types on a dialect.
+// It is embedded by the dialects to implement RegisterType.
+type typeRegistry struct {
+	mu    sync.RWMutex            // mu guards types
+	types map[reflect.Type]string // types maps Go types to SQL types
+}
+
+// RegisterType makes the dialect map values of the Go type typ to the SQL type sqlType.
+func (r *typeRegistry) RegisterType(typ reflect.Type, sqlType string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.types == nil {
+		r.types = make(map[reflect.Type]string)
+	}
+	r.types[typ] = sqlType
+}
+
+// customDataTypeOf returns the SQL type of t when it is registered on the dialect or when
+// it implements driver.Valuer or sql.Scanner, in which case the type is inferred from its value.
+func (r *typeRegistry) customDataTypeOf(d Dialect, t reflect.Value) (string, bool) {
+	r.mu.RLock()
+	sqlType, ok := r.types[t.Type()]
+	r.mu.RUnlock()
+	if ok {
+		return sqlType, true
+	}
+
+	typ := t.Type()
+	if typ == timeType {
+		return "", false
+	}
+	if !typ.Implements(valuerType) && !reflect.PointerTo(typ).Implements(valuerType) &&
+		!reflect.PointerTo(typ).Implements(scannerType) {
+		return "", false
+	}
+
+	// Use the type of the value produced by driver.Valuer, if it is not NULL.
+	if value := driverValue(t); value != nil {
+		return d.DataTypeOf(reflect.ValueOf(value)), true
+	}
+	switch typ.Kind() {
+	case reflect.Struct:
+		// Wrappers such as sql.NullString keep the wrapped value in their first field.
+		if typ.NumField() > 0 && typ.Field(0).IsExported() {
+			return d.DataTypeOf(reflect.New(typ.Field(0).Type).Elem()), true
+		}
+	case reflect.Map, reflect.Slice:
+		// Byte slices map to the binary type, other maps and slices are usually encoded as text.
+		if typ.Kind() == reflect.Slice && typ.Elem().Kind() == reflect.Uint8 {
+			return "", false
+		}
+	default:
+		// Other kinds map through their underlying kind.
+		return "", false
+	}
+	// Fall back to the string type of the dialect.
+	return d.DataTypeOf(reflect.ValueOf("")), true
+}
+
+// driverValue returns the driver value of t if it implements driver.Valuer, or nil.
+func driverValue(t reflect.Value) (value driver.Value) {
+	// Call the method on a pointer copy so that pointer receivers are found too.
+	ptr := reflect.New(t.Type())
+	ptr.Elem().Set(t)
+	valuer, ok := ptr.Interface().(driver.Valuer)
+	if !ok {
+		return nil
+	}
+	// A Value method may not cope with the zero value, treat that as NULL.
+	defer func() {
+		if recover() != nil {
+			value = nil
+		}
+	}()
+	value, _ = valuer.Value()
+	return value
+}
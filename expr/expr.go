@@ -0,0 +1,199 @@
+// Package expr provides typed conditions for Session.Where, Or and Not.
+// Every condition implements clause.Expression; column names are quoted for the dialect.
+package expr
+
+import (
+	"reflect"
+	"strings"
+	"tsorm/clause"
+	"tsorm/dialect"
+)
+
+// comparison compares a column with a value using a binary operator.
+type comparison struct {
+	column string
+	op     string
+	value  interface{}
+}
+
+// Build returns "column op ?".
+func (c comparison) Build(d dialect.Dialect) (string, []interface{}) {
+	return clause.Quote(d, c.column) + " " + c.op + " ?", []interface{}{c.value}
+}
+
+// Eq returns the condition column = value. A nil value gives column IS NULL.
+func Eq(column string, value interface{}) clause.Expression {
+	if value == nil {
+		return IsNull(column)
+	}
+	return comparison{column, "=", value}
+}
+
+// Neq returns the condition column <> value. A nil value gives column IS NOT NULL.
+func Neq(column string, value interface{}) clause.Expression {
+	if value == nil {
+		return IsNotNull(column)
+	}
+	return comparison{column, "<>", value}
+}
+
+// Gt returns the condition column > value.
+func Gt(column string, value interface{}) clause.Expression {
+	return comparison{column, ">", value}
+}
+
+// Gte returns the condition column >= value.
+func Gte(column string, value interface{}) clause.Expression {
+	return comparison{column, ">=", value}
+}
+
+// Lt returns the condition column < value.
+func Lt(column string, value interface{}) clause.Expression {
+	return comparison{column, "<", value}
+}
+
+// Lte returns the condition column <= value.
+func Lte(column string, value interface{}) clause.Expression {
+	return comparison{column, "<=", value}
+}
+
+// Like returns the condition column LIKE pattern.
+func Like(column string, pattern string) clause.Expression {
+	return comparison{column, "LIKE", pattern}
+}
+
+// in matches a column against a list of values.
+type in struct {
+	column string
+	values []interface{}
+}
+
+// Build returns "column IN (?, ?)", or a false condition for an empty list.
+func (c in) Build(d dialect.Dialect) (string, []interface{}) {
+	if len(c.values) == 0 {
+		return "1 = 0", nil
+	}
+	return clause.Quote(d, c.column) + " IN (" + strings.Repeat("?, ", len(c.values)-1) + "?)", c.values
+}
+
+// In returns the condition column IN (values...). A single slice argument is expanded,
+// so In("ID", 1, 2) and In("ID", []int{1, 2}) are the same. An empty list matches nothing.
+func In(column string, values ...interface{}) clause.Expression {
+	if len(values) == 1 {
+		if v := reflect.ValueOf(values[0]); v.Kind() == reflect.Slice && v.Type().Elem().Kind() != reflect.Uint8 {
+			values = make([]interface{}, v.Len())
+			for i := range values {
+				values[i] = v.Index(i).Interface()
+			}
+		}
+	}
+	return in{column, values}
+}
+
+// isNull checks whether a column is NULL.
+type isNull struct {
+	column string
+	not    bool
+}
+
+// Build returns "column IS NULL" or "column IS NOT NULL".
+func (c isNull) Build(d dialect.Dialect) (string, []interface{}) {
+	if c.not {
+		return clause.Quote(d, c.column) + " IS NOT NULL", nil
+	}
+	return clause.Quote(d, c.column) + " IS NULL", nil
+}
+
+// IsNull returns the condition column IS NULL.
+func IsNull(column string) clause.Expression {
+	return isNull{column: column}
+}
+
+// IsNotNull returns the condition column IS NOT NULL.
+func IsNotNull(column string) clause.Expression {
+	return isNull{column: column, not: true}
+}
+
+// between checks whether a column lies in a closed range.
+type between struct {
+	column    string
+	low, high interface{}
+}
+
+// Build returns "column BETWEEN ? AND ?".
+func (c between) Build(d dialect.Dialect) (string, []interface{}) {
+	return clause.Quote(d, c.column) + " BETWEEN ? AND ?", []interface{}{c.low, c.high}
+}
+
+// Between returns the condition column BETWEEN low AND high.
+func Between(column string, low, high interface{}) clause.Expression {
+	return between{column, low, high}
+}
+
+// junction joins conditions with AND or OR.
+type junction struct {
+	op    string
+	exprs []clause.Expression
+}
+
+// Build joins the conditions with the operator, parenthesising the ones which could bind differently.
+func (j junction) Build(d dialect.Dialect) (string, []interface{}) {
+	if len(j.exprs) == 1 {
+		return j.exprs[0].Build(d)
+	}
+	parts := make([]string, len(j.exprs))
+	var vars []interface{}
+	for i, e := range j.exprs {
+		sql, exprVars := e.Build(d)
+		if needsParens(e) {
+			sql = "(" + sql + ")"
+		}
+		parts[i] = sql
+		vars = append(vars, exprVars...)
+	}
+	return strings.Join(parts, " "+j.op+" "), vars
+}
+
+// needsParens reports whether e must be parenthesised when combined with other conditions:
+// compound conditions and raw SQL, which may contain operators of its own.
+func needsParens(e clause.Expression) bool {
+	switch e := e.(type) {
+	case junction:
+		return len(e.exprs) > 1
+	case comparison, in, isNull, between, not:
+		return false
+	}
+	return true
+}
+
+// And returns the condition which holds when every condition holds. It is true for no conditions.
+func And(exprs ...clause.Expression) clause.Expression {
+	if len(exprs) == 0 {
+		return clause.Expr{SQL: "1 = 1"}
+	}
+	return junction{"AND", exprs}
+}
+
+// Or returns the condition which holds when any condition holds. It is false for no conditions.
+func Or(exprs ...clause.Expression) clause.Expression {
+	if len(exprs) == 0 {
+		return clause.Expr{SQL: "1 = 0"}
+	}
+	return junction{"OR", exprs}
+}
+
+// not negates a condition.
+type not struct {
+	expr clause.Expression
+}
+
+// Build returns "NOT (condition)".
+func (n not) Build(d dialect.Dialect) (string, []interface{}) {
+	sql, vars := n.expr.Build(d)
+	return "NOT (" + sql + ")", vars
+}
+
+// Not returns the negation of the condition.
+func Not(e clause.Expression) clause.Expression {
+	return not{e}
+}
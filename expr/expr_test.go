@@ -0,0 +1,46 @@
+package expr
+
+import (
+	"reflect"
+	"testing"
+	"tsorm/clause"
+	"tsorm/dialect"
+)
+
+// TestBuild tests the SQL and variables generated by every condition.
+func TestBuild(t *testing.T) {
+	d, _ := dialect.GetDialect("sqlite3")
+	testCases := []struct {
+		expr clause.Expression
+		sql  string
+		vars []interface{}
+	}{
+		{Eq("Name", "Tom"), `"Name" = ?`, []interface{}{"Tom"}},
+		{Eq("DeletedAt", nil), `"DeletedAt" IS NULL`, nil},
+		{Neq("Age", 18), `"Age" <> ?`, []interface{}{18}},
+		{Gt("Age", 18), `"Age" > ?`, []interface{}{18}},
+		{Like("Name", "T%"), `"Name" LIKE ?`, []interface{}{"T%"}},
+		{In("ID", 1, 2), `"ID" IN (?, ?)`, []interface{}{1, 2}},
+		{In("ID", []int{1, 2, 3}), `"ID" IN (?, ?, ?)`, []interface{}{1, 2, 3}},
+		{In("ID"), `1 = 0`, nil},
+		{IsNull("Email"), `"Email" IS NULL`, nil},
+		{Between("Age", 18, 30), `"Age" BETWEEN ? AND ?`, []interface{}{18, 30}},
+		{Not(Eq("Name", "Tom")), `NOT ("Name" = ?)`, []interface{}{"Tom"}},
+		{
+			And(Eq("Name", "Tom"), Or(Gt("Age", 18), IsNull("Age"))),
+			`"Name" = ? AND ("Age" > ? OR "Age" IS NULL)`,
+			[]interface{}{"Tom", 18},
+		},
+		{
+			Or(clause.Expr{SQL: "Age > ? AND Age < ?", Vars: []interface{}{1, 9}}, Eq("Name", "Sam")),
+			`(Age > ? AND Age < ?) OR "Name" = ?`,
+			[]interface{}{1, 9, "Sam"},
+		},
+	}
+	for _, tc := range testCases {
+		sql, vars := tc.expr.Build(d)
+		if sql != tc.sql || !reflect.DeepEqual(vars, tc.vars) {
+			t.Errorf("got %s %v, want %s %v", sql, vars, tc.sql, tc.vars)
+		}
+	}
+}
@@ -1,50 +1,95 @@
 package tsorm
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"strings"
+	"time"
 	"tsorm/dialect"
 	"tsorm/log"
+	"tsorm/schema"
 	"tsorm/session"
 )
 
 // Engine represents the database engine.
 type Engine struct {
-	db      *sql.DB         // Underlying database connection
-	dialect dialect.Dialect // Database dialect
+	db      *sql.DB          // Underlying database connection
+	dialect dialect.Dialect  // Database dialect
+	logger  log.Logger       // Logger used by the engine and its sessions
+	namer   schema.Namer     // Naming strategy used to map models to tables
+	nowFunc func() time.Time // Clock used to fill automatic timestamps
 }
 
 // NewEngine creates a new database engine.
-func NewEngine(driver string, source string) (e *Engine, err error) {
+// The dialect is looked up by driver name unless overridden by WithDialect or WithDialectAlias.
+func NewEngine(driver string, source string, opts ...Option) (e *Engine, err error) {
+	o := newOptions(opts)
 	// Open a database connection.
 	db, err := sql.Open(driver, source)
 	if err != nil {
-		log.Error(err)
+		o.logger.Error(err)
 		return nil, err
 	}
 
+	if e, err = newEngine(db, driver, o); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	return
+}
+
+// NewEngineFromDB creates a new database engine on top of an existing connection pool.
+// As the driver name of db is unknown, the dialect must be selected with WithDialect.
+func NewEngineFromDB(db *sql.DB, opts ...Option) (*Engine, error) {
+	return newEngine(db, "", newOptions(opts))
+}
+
+// newOptions returns the settings of the given options.
+func newOptions(opts []Option) *options {
+	o := &options{logger: log.Default()}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if o.logger == nil {
+		o.logger = log.Default()
+	}
+	return o
+}
+
+// newEngine applies the options to db and initializes the Engine.
+func newEngine(db *sql.DB, driver string, o *options) (e *Engine, err error) {
+	// Apply the connection pool settings.
+	for _, set := range o.pool {
+		set(db)
+	}
+
 	// Ping the database to ensure connectivity.
 	if err = db.Ping(); err != nil {
-		log.Error(err)
-		return
+		o.logger.Error(err)
+		return nil, err
 	}
 
 	// Get the dialect for the specified driver.
-	dial, ok := dialect.GetDialect("sqlite3")
+	name := o.dialectName(driver)
+	dial, ok := dialect.GetDialect(name)
 	if !ok {
-		log.Errorf("dialect %s Not Found", driver)
-		return
+		err = fmt.Errorf("dialect %q not found", name)
+		o.logger.Error(err)
+		return nil, err
 	}
 
 	// Initialize the Engine with the database connection and dialect.
 	e = &Engine{
 		db:      db,
 		dialect: dial,
+		logger:  o.logger,
+		namer:   o.namer,
+		nowFunc: o.nowFunc,
 	}
 
 	// Log successful database connection.
-	log.Info("Connect database success")
+	e.logger.Info("Connect database success")
 	return
 }
 
@@ -52,15 +97,25 @@ func NewEngine(driver string, source string) (e *Engine, err error) {
 func (e *Engine) Close() {
 	// Close the underlying database connection.
 	if err := e.db.Close(); err != nil {
-		log.Error("Failed to close database:", err)
+		e.logger.Error("Failed to close database:", err)
 	} else {
-		log.Info("Close database success")
+		e.logger.Info("Close database success")
 	}
 }
 
 // NewSession creates a new session associated with the engine.
 func (e *Engine) NewSession() *session.Session {
-	return session.NewSession(e.db, e.dialect)
+	return session.NewSession(e.db, e.dialect, session.WithLogger(e.logger), session.WithNamer(e.namer), session.WithNowFunc(e.nowFunc))
+}
+
+// Columns returns the columns of the given table as reported by the database.
+func (e *Engine) Columns(tableName string) ([]dialect.ColumnInfo, error) {
+	return e.NewSession().Columns(tableName)
+}
+
+// Indexes returns the indexes of the given table as reported by the database.
+func (e *Engine) Indexes(tableName string) ([]dialect.IndexInfo, error) {
+	return e.NewSession().Indexes(tableName)
 }
 
 // TxFunc represents a function signature for transactions.
@@ -68,7 +123,13 @@ type TxFunc func(s *session.Session) (result interface{}, err error)
 
 // Transaction performs a transaction with the provided function.
 func (e *Engine) Transaction(f TxFunc) (result interface{}, err error) {
-	s := e.NewSession()
+	return e.TransactionContext(context.Background(), f)
+}
+
+// TransactionContext performs a transaction bound to ctx with the provided function.
+// The session passed to f carries ctx, so its statements are canceled together with ctx.
+func (e *Engine) TransactionContext(ctx context.Context, f TxFunc) (result interface{}, err error) {
+	s := e.NewSession().WithContext(ctx)
 	if err := s.Begin(); err != nil {
 		return nil, err
 	}
@@ -100,48 +161,84 @@ func difference(a []string, b []string) (diff []string) {
 	return
 }
 
+// migrateSQL returns the DDL statements, one per element, that add addCols to the table
+// and delete delCols from it. Table and column names are quoted for the dialect.
+func migrateSQL(d dialect.Dialect, table *schema.Schema, addCols []string, delCols []string) []string {
+	var stmts []string
+	name := d.Quote(table.Name)
+	for _, col := range addCols {
+		f := table.GetField(col)
+		stmts = append(stmts, fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", name, d.Quote(f.Column), f.Type))
+	}
+
+	// If no columns are to be deleted, return.
+	if len(delCols) == 0 {
+		return stmts
+	}
+
+	// Drop columns in place where the dialect can, keeping keys, indexes, defaults and constraints.
+	if d.DropColumnInPlace() {
+		for _, col := range delCols {
+			stmts = append(stmts, fmt.Sprintf("ALTER TABLE %s DROP COLUMN %s", name, d.Quote(col)))
+		}
+		return stmts
+	}
+
+	// Rename the table and recreate it to delete columns.
+	temp := d.Quote("temp_" + table.Name)
+	var fields []string
+	for _, col := range table.FieldNames {
+		fields = append(fields, d.Quote(col))
+	}
+	fieldStr := strings.Join(fields, ", ")
+	return append(stmts,
+		fmt.Sprintf("CREATE TABLE %s AS SELECT %s FROM %s", temp, fieldStr, name),
+		fmt.Sprintf("DROP TABLE %s", name),
+		fmt.Sprintf("ALTER TABLE %s RENAME TO %s", temp, name),
+	)
+}
+
 // Migrate migrates the schema of the given value to the database.
 func (e *Engine) Migrate(value interface{}) error {
-	_, err := e.Transaction(func(s *session.Session) (result interface{}, err error) {
+	return e.MigrateContext(context.Background(), value)
+}
+
+// MigrateContext migrates the schema of the given value to the database within a transaction bound to ctx.
+func (e *Engine) MigrateContext(ctx context.Context, value interface{}) error {
+	_, err := e.TransactionContext(ctx, func(s *session.Session) (result interface{}, err error) {
+		if _, err = s.ModelE(value); err != nil {
+			return
+		}
 		// If the table does not exist, create it.
-		if !s.Model(value).HasTable() {
-			log.Infof("table %s doesn't exist", s.RefTable().Name)
+		if !s.HasTable() {
+			e.logger.Infof("table %s doesn't exist", s.RefTable().Name)
 			return nil, s.CreateTable()
 		}
 
 		// Get the table schema.
 		table := s.RefTable()
 
-		// Query a row from the table to get the columns.
-		rows, _ := s.Raw(fmt.Sprintf("SELECT * FROM %s LIMIT 1", table.Name)).QueryRows()
-		columns, _ := rows.Columns()
+		// Introspect the table to get the existing columns.
+		existing, err := s.Columns(table.Name)
+		if err != nil {
+			return
+		}
+		var columns []string
+		for _, column := range existing {
+			columns = append(columns, column.Name)
+		}
 
 		// Find columns to add and delete.
 		addCols := difference(table.FieldNames, columns)
 		delCols := difference(columns, table.FieldNames)
-		log.Infof("added cols %v, deleted cols %v", addCols, delCols)
+		e.logger.Infof("added cols %v, deleted cols %v", addCols, delCols)
 
-		// Add new columns.
-		for _, col := range addCols {
-			f := table.GetField(col)
-			sqlStr := fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s;", table.Name, f.Name, f.Type)
+		// Add new columns, then rebuild the table without the deleted ones.
+		for _, sqlStr := range migrateSQL(e.dialect, table, addCols, delCols) {
 			if _, err = s.Raw(sqlStr).Exec(); err != nil {
 				return
 			}
 		}
-
-		// If no columns are to be deleted, return.
-		if len(delCols) == 0 {
-			return
-		}
-
-		// Rename the table and recreate it to delete columns.
-		temp := "temp_" + table.Name
-		fieldStr := strings.Join(table.FieldNames, ",")
-		s.Raw(fmt.Sprintf("CREATE TABLE %s AS SELECT %s from %s;", temp, fieldStr, table.Name))
-		s.Raw(fmt.Sprintf("DROP TABLE %s;", table.Name))
-		s.Raw(fmt.Sprintf("ALTER TABLE %s RENAME TO %s;", temp, table.Name))
-		_, err = s.Exec()
 		return
 	})
 	return err
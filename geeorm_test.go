@@ -1,12 +1,18 @@
 package tsorm
 
 import (
+	"context"
+	"database/sql"
 	"errors"
+	"fmt"
 	"reflect"
+	"sync"
 	"testing"
+	"tsorm/dialect"
+	"tsorm/schema"
 	"tsorm/session"
 
-	_ "github.com/mattn/go-sqlite3"
+	"github.com/mattn/go-sqlite3"
 )
 
 // OpenDB opens a database connection for testing purposes.
@@ -61,6 +67,23 @@ func TestEngine_TransactionCommit(t *testing.T) {
 	}
 }
 
+// TestEngine_TransactionContext tests that a canceled context aborts the transaction.
+func TestEngine_TransactionContext(t *testing.T) {
+	engine := OpenDB(t)
+	defer engine.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	called := false
+	_, err := engine.TransactionContext(ctx, func(s *session.Session) (result interface{}, err error) {
+		called = true
+		return nil, nil
+	})
+	if !errors.Is(err, context.Canceled) || called {
+		t.Fatal("expected canceled transaction, got", err)
+	}
+}
+
 // TestEngine_Migrate tests schema migration functionality.
 func TestEngine_Migrate(t *testing.T) {
 	engine := OpenDB(t)
@@ -78,3 +101,143 @@ func TestEngine_Migrate(t *testing.T) {
 		t.Fatal("Failed to migrate table User, got columns", columns)
 	}
 }
+
+// recordLogger is a log.Logger that records every message it receives.
+type recordLogger struct {
+	messages []string
+}
+
+func (l *recordLogger) Info(v ...interface{})                  { l.messages = append(l.messages, fmt.Sprint(v...)) }
+func (l *recordLogger) Infof(format string, v ...interface{})  { l.Info(fmt.Sprintf(format, v...)) }
+func (l *recordLogger) Error(v ...interface{})                 { l.Info(v...) }
+func (l *recordLogger) Errorf(format string, v ...interface{}) { l.Info(fmt.Sprintf(format, v...)) }
+
+// TestNewEngineFromDB tests creating an engine on top of an existing pool with options.
+func TestNewEngineFromDB(t *testing.T) {
+	db, err := sql.Open("sqlite3", "ts.db")
+	if err != nil {
+		t.Fatal("failed to open database", err)
+	}
+	defer db.Close()
+
+	// Without an explicit dialect the engine cannot be created.
+	if _, err := NewEngineFromDB(db); err == nil {
+		t.Fatal("expected an error for a missing dialect")
+	}
+
+	logger := &recordLogger{}
+	engine, err := NewEngineFromDB(db, WithDialect("sqlite3"), WithMaxOpenConns(3), WithLogger(logger))
+	if err != nil {
+		t.Fatal("failed to create engine", err)
+	}
+	if db.Stats().MaxOpenConnections != 3 {
+		t.Fatal("failed to apply pool options, got", db.Stats().MaxOpenConnections)
+	}
+
+	// Sessions created by the engine log through the engine logger.
+	_, _ = engine.NewSession().Raw("SELECT 1").Exec()
+	if len(logger.messages) < 2 {
+		t.Fatal("failed to log through the custom logger, got", logger.messages)
+	}
+}
+
+// registerAlias registers the sqlite3 driver under a custom name once, as sql.Register panics on duplicates.
+var registerAlias sync.Once
+
+// TestNewEngine_DialectAlias tests resolving the dialect of a custom driver name through an alias.
+func TestNewEngine_DialectAlias(t *testing.T) {
+	registerAlias.Do(func() { sql.Register("sqlite3_alias", &sqlite3.SQLiteDriver{}) })
+
+	if _, err := NewEngine("sqlite3_alias", "ts.db"); err == nil {
+		t.Fatal("expected an error for an unknown dialect")
+	}
+	engine, err := NewEngine("sqlite3_alias", "ts.db", WithDialectAlias("sqlite3_alias", "sqlite3"))
+	if err != nil {
+		t.Fatal("failed to resolve dialect alias", err)
+	}
+	engine.Close()
+}
+
+// TestNewEngine_OpenError tests that a failure to open the database is logged through WithLogger.
+func TestNewEngine_OpenError(t *testing.T) {
+	logger := &recordLogger{}
+	if _, err := NewEngine("unknown", "ts.db", WithLogger(logger)); err == nil {
+		t.Fatal("expected an error for an unknown driver")
+	}
+	if len(logger.messages) != 1 {
+		t.Fatal("failed to log through the custom logger, got", logger.messages)
+	}
+}
+
+// TestMigrateSQL tests the DDL statements generated by Migrate for each dialect.
+func TestMigrateSQL(t *testing.T) {
+	tests := []struct {
+		dialect  string
+		expected []string
+	}{
+		{"sqlite3", []string{
+			`ALTER TABLE "User" ADD COLUMN "Age" integer`,
+			`CREATE TABLE "temp_User" AS SELECT "Name", "Age" FROM "User"`,
+			`DROP TABLE "User"`,
+			`ALTER TABLE "temp_User" RENAME TO "User"`,
+		}},
+		{"mysql", []string{
+			"ALTER TABLE `User` ADD COLUMN `Age` BIGINT",
+			"ALTER TABLE `User` DROP COLUMN `XXX`",
+		}},
+		{"postgres", []string{
+			`ALTER TABLE "User" ADD COLUMN "Age" integer`,
+			`ALTER TABLE "User" DROP COLUMN "XXX"`,
+		}},
+	}
+	for _, tt := range tests {
+		d, _ := dialect.GetDialect(tt.dialect)
+		table := schema.Parse(&User{}, d)
+		stmts := migrateSQL(d, table, []string{"Age"}, []string{"XXX"})
+		if !reflect.DeepEqual(stmts, tt.expected) {
+			t.Errorf("%s: got %q, want %q", tt.dialect, stmts, tt.expected)
+		}
+	}
+
+	// The strategy follows the capability of the dialect, not its registered name.
+	sqlite, _ := dialect.GetDialect("sqlite3")
+	custom := customDialect{sqlite}
+	stmts := migrateSQL(custom, schema.Parse(&User{}, custom), nil, []string{"XXX"})
+	if len(stmts) != 3 || stmts[1] != `DROP TABLE "User"` {
+		t.Errorf("custom sqlite3 dialect: got %q, want a rebuild", stmts)
+	}
+}
+
+// customDialect is a dialect registered under no name, wrapping another one.
+type customDialect struct {
+	dialect.Dialect
+}
+
+// TestEngine_ColumnsAndIndexes tests the introspection of an existing table.
+func TestEngine_ColumnsAndIndexes(t *testing.T) {
+	engine := OpenDB(t)
+	defer engine.Close()
+
+	s := engine.NewSession()
+	_, _ = s.Raw("DROP TABLE IF EXISTS Introspected;").Exec()
+	_, _ = s.Raw("CREATE TABLE Introspected(Id integer PRIMARY KEY, Name text NOT NULL DEFAULT 'none', Age integer);").Exec()
+	_, _ = s.Raw("CREATE UNIQUE INDEX idx_name_age ON Introspected(Name, Age);").Exec()
+
+	columns, err := engine.Columns("Introspected")
+	if err != nil || len(columns) != 3 {
+		t.Fatal("failed to read columns", columns, err)
+	}
+	name := columns[1]
+	if name.Name != "Name" || name.Type != "TEXT" || !name.NotNull || name.Default == nil || *name.Default != "'none'" {
+		t.Fatal("unexpected column", name)
+	}
+	if !columns[0].PrimaryKey || columns[2].NotNull || columns[2].Default != nil {
+		t.Fatal("unexpected columns", columns)
+	}
+
+	indexes, err := engine.Indexes("Introspected")
+	expected := []dialect.IndexInfo{{Name: "idx_name_age", Unique: true, Columns: []string{"Name", "Age"}}}
+	if err != nil || !reflect.DeepEqual(indexes, expected) {
+		t.Fatal("unexpected indexes", indexes, err)
+	}
+}
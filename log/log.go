@@ -1,6 +1,7 @@
 package log
 
 import (
+	"fmt"
 	"io"
 	"log"
 	"os"
@@ -54,3 +55,35 @@ func SetLevel(level int) {
 		infoLog.SetOutput(io.Discard)
 	}
 }
+
+// Logger is the interface used by engines and sessions to emit log messages.
+type Logger interface {
+	Info(v ...interface{})                  // Info logs an info message with a newline.
+	Infof(format string, v ...interface{})  // Infof logs a formatted info message.
+	Error(v ...interface{})                 // Error logs an error message with a newline.
+	Errorf(format string, v ...interface{}) // Errorf logs a formatted error message.
+}
+
+// stdLogger adapts the package-level loggers to the Logger interface.
+type stdLogger struct{}
+
+// Info logs a message with a newline through infoLog.
+func (stdLogger) Info(v ...interface{}) { _ = infoLog.Output(2, fmt.Sprintln(v...)) }
+
+// Infof logs a formatted message through infoLog.
+func (stdLogger) Infof(format string, v ...interface{}) {
+	_ = infoLog.Output(2, fmt.Sprintf(format, v...))
+}
+
+// Error logs a message with a newline through errorLog.
+func (stdLogger) Error(v ...interface{}) { _ = errorLog.Output(2, fmt.Sprintln(v...)) }
+
+// Errorf logs a formatted message through errorLog.
+func (stdLogger) Errorf(format string, v ...interface{}) {
+	_ = errorLog.Output(2, fmt.Sprintf(format, v...))
+}
+
+// Default returns the Logger backed by the package-level loggers, honouring SetLevel.
+func Default() Logger {
+	return stdLogger{}
+}
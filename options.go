@@ -0,0 +1,92 @@
+package tsorm
+
+import (
+	"database/sql"
+	"time"
+	"tsorm/log"
+	"tsorm/schema"
+)
+
+// options holds the settings collected from the Option values passed to an engine constructor.
+type options struct {
+	dialect string             // dialect is the explicit dialect name, overriding the driver name
+	aliases map[string]string  // aliases maps driver names to dialect names
+	pool    []func(db *sql.DB) // pool holds the connection pool settings applied to the database
+	logger  log.Logger         // logger is the logger used by the engine and its sessions
+	namer   schema.Namer       // namer maps model names to table and column names
+	nowFunc func() time.Time   // nowFunc returns the current time for automatic timestamps
+}
+
+// Option configures an Engine created by NewEngine or NewEngineFromDB.
+type Option func(*options)
+
+// WithDialect selects the registered dialect by name instead of deriving it from the driver.
+func WithDialect(name string) Option {
+	return func(o *options) {
+		o.dialect = name
+	}
+}
+
+// WithDialectAlias makes the engine use the dialect named dialectName for the given driver,
+// e.g. WithDialectAlias("sqlite", "sqlite3").
+func WithDialectAlias(driver string, dialectName string) Option {
+	return func(o *options) {
+		if o.aliases == nil {
+			o.aliases = make(map[string]string)
+		}
+		o.aliases[driver] = dialectName
+	}
+}
+
+// WithMaxOpenConns sets the maximum number of open connections to the database.
+func WithMaxOpenConns(n int) Option {
+	return func(o *options) {
+		o.pool = append(o.pool, func(db *sql.DB) { db.SetMaxOpenConns(n) })
+	}
+}
+
+// WithMaxIdleConns sets the maximum number of idle connections kept in the pool.
+func WithMaxIdleConns(n int) Option {
+	return func(o *options) {
+		o.pool = append(o.pool, func(db *sql.DB) { db.SetMaxIdleConns(n) })
+	}
+}
+
+// WithConnMaxLifetime sets the maximum amount of time a connection may be reused.
+func WithConnMaxLifetime(d time.Duration) Option {
+	return func(o *options) {
+		o.pool = append(o.pool, func(db *sql.DB) { db.SetConnMaxLifetime(d) })
+	}
+}
+
+// WithLogger sets the logger used by the engine and its sessions.
+func WithLogger(logger log.Logger) Option {
+	return func(o *options) {
+		o.logger = logger
+	}
+}
+
+// WithNamer sets the naming strategy used to map models to tables and columns.
+func WithNamer(namer schema.Namer) Option {
+	return func(o *options) {
+		o.namer = namer
+	}
+}
+
+// WithNowFunc sets the clock used to fill CreatedAt and UpdatedAt fields, so tests can freeze time.
+func WithNowFunc(now func() time.Time) Option {
+	return func(o *options) {
+		o.nowFunc = now
+	}
+}
+
+// dialectName returns the name of the dialect to use for the given driver.
+func (o *options) dialectName(driver string) string {
+	if o.dialect != "" {
+		return o.dialect
+	}
+	if alias, ok := o.aliases[driver]; ok {
+		return alias
+	}
+	return driver
+}
@@ -0,0 +1,58 @@
+package schema
+
+import (
+	"reflect"
+	"sync"
+	"tsorm/dialect"
+)
+
+// cacheKey identifies a schema parsed for a model type, dialect and naming strategy.
+type cacheKey struct {
+	modelType reflect.Type
+	dialect   dialect.Dialect
+	namer     Namer
+}
+
+// cache holds the schemas parsed by ParseCached, keyed by cacheKey.
+var cache sync.Map
+
+// ParseCached returns the schema of the model type of dest, parsing it only on first use.
+// The returned schema is shared and must not be modified; its Model is a zero value of the
+// model type rather than dest. Types registered on the dialect after the first parse of a
+// model do not affect its cached schema. It panics if the model cannot be parsed.
+func ParseCached(dest interface{}, d dialect.Dialect, opts ...Option) *Schema {
+	s, err := ParseCachedE(dest, d, opts...)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// ParseCachedE is like ParseCached but returns an error if the model cannot be parsed.
+// Models which fail to parse are not cached.
+func ParseCachedE(dest interface{}, d dialect.Dialect, opts ...Option) (*Schema, error) {
+	o := options{namer: identityNamer{}}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	modelType, err := modelType(dest)
+	if err != nil {
+		return nil, err
+	}
+	// Namers that cannot be map keys are not cached.
+	if !reflect.TypeOf(o.namer).Comparable() {
+		return ParseE(reflect.New(modelType).Interface(), d, opts...)
+	}
+
+	key := cacheKey{modelType: modelType, dialect: d, namer: o.namer}
+	if s, ok := cache.Load(key); ok {
+		return s.(*Schema), nil
+	}
+	parsed, err := ParseE(reflect.New(modelType).Interface(), d, opts...)
+	if err != nil {
+		return nil, err
+	}
+	s, _ := cache.LoadOrStore(key, parsed)
+	return s.(*Schema), nil
+}
@@ -0,0 +1,103 @@
+package schema
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Namer maps Go struct and field names to table and column names.
+type Namer interface {
+	// TableName returns the table name for the given struct name.
+	TableName(structName string) string
+
+	// ColumnName returns the column name for the given struct field name.
+	ColumnName(fieldName string) string
+}
+
+// identityNamer is the default Namer, which uses Go names verbatim.
+type identityNamer struct{}
+
+// TableName returns the struct name unchanged.
+func (identityNamer) TableName(structName string) string {
+	return structName
+}
+
+// ColumnName returns the field name unchanged.
+func (identityNamer) ColumnName(fieldName string) string {
+	return fieldName
+}
+
+// Tabler is implemented by models that choose their own table name.
+// The name is used verbatim, without going through the Namer.
+type Tabler interface {
+	TableName() string
+}
+
+// NamingStrategy is a Namer that converts Go names to snake_case,
+// prefixes table names and pluralises them.
+type NamingStrategy struct {
+	TablePrefix   string // TablePrefix is prepended to every table name
+	SingularTable bool   // SingularTable disables the pluralisation of table names
+}
+
+// TableName returns the prefixed, snake_cased and pluralised table name, e.g. "OrderItem" becomes "order_items".
+func (ns NamingStrategy) TableName(structName string) string {
+	name := toSnakeCase(structName)
+	if !ns.SingularTable {
+		name = pluralize(name)
+	}
+	return ns.TablePrefix + name
+}
+
+// ColumnName returns the snake_cased column name, e.g. "UserID" becomes "user_id".
+func (ns NamingStrategy) ColumnName(fieldName string) string {
+	return toSnakeCase(fieldName)
+}
+
+// toSnakeCase converts a Go identifier to snake_case, keeping acronyms together.
+func toSnakeCase(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			// Start a new word after a lower case letter or digit, or at the last upper case
+			// letter of an acronym followed by a lower case letter, as in "HTTPServer".
+			if i > 0 && (!unicode.IsUpper(runes[i-1]) ||
+				(i+1 < len(runes) && unicode.IsLower(runes[i+1]))) && runes[i-1] != '_' {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// irregularPlurals holds the plurals that do not follow the suffix rules of pluralize.
+var irregularPlurals = map[string]string{
+	"child":  "children",
+	"man":    "men",
+	"person": "people",
+	"woman":  "women",
+}
+
+// pluralize returns the English plural of the last word of a snake_case name.
+func pluralize(name string) string {
+	prefix, word := "", name
+	if i := strings.LastIndexByte(name, '_'); i >= 0 {
+		prefix, word = name[:i+1], name[i+1:]
+	}
+	if plural, ok := irregularPlurals[word]; ok {
+		return prefix + plural
+	}
+	switch {
+	case strings.HasSuffix(word, "s"), strings.HasSuffix(word, "x"), strings.HasSuffix(word, "z"),
+		strings.HasSuffix(word, "ch"), strings.HasSuffix(word, "sh"):
+		word += "es"
+	case len(word) > 1 && strings.HasSuffix(word, "y") && !strings.ContainsRune("aeiou", rune(word[len(word)-2])):
+		word = word[:len(word)-1] + "ies"
+	default:
+		word += "s"
+	}
+	return prefix + word
+}
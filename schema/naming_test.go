@@ -0,0 +1,67 @@
+package schema
+
+import "testing"
+
+// TestNamingStrategy tests the table and column names produced by NamingStrategy.
+func TestNamingStrategy(t *testing.T) {
+	ns := NamingStrategy{TablePrefix: "t_"}
+
+	tables := map[string]string{
+		"User":       "t_users",
+		"OrderItem":  "t_order_items",
+		"Category":   "t_categories",
+		"Day":        "t_days",
+		"Box":        "t_boxes",
+		"Person":     "t_people",
+		"HTTPServer": "t_http_servers",
+	}
+	for in, want := range tables {
+		if got := ns.TableName(in); got != want {
+			t.Errorf("TableName(%s) got %s, want %s", in, got, want)
+		}
+	}
+
+	columns := map[string]string{
+		"ID":        "id",
+		"Id":        "id",
+		"UserID":    "user_id",
+		"FirstName": "first_name",
+		"Address2":  "address2",
+		"HTTPCode":  "http_code",
+	}
+	for in, want := range columns {
+		if got := ns.ColumnName(in); got != want {
+			t.Errorf("ColumnName(%s) got %s, want %s", in, got, want)
+		}
+	}
+
+	if got := (NamingStrategy{SingularTable: true}).TableName("OrderItem"); got != "order_item" {
+		t.Errorf("TableName(OrderItem) got %s, want order_item", got)
+	}
+}
+
+// Legacy is a model with its own table name and an explicit column name.
+type Legacy struct {
+	ID   int
+	Name string `tsorm:"column:legacy_name"`
+}
+
+// TableName implements Tabler.
+func (Legacy) TableName() string {
+	return "tbl_legacy"
+}
+
+// TestParseTableNameAndColumnTag tests the TableName method and the "column:" tag setting.
+func TestParseTableNameAndColumnTag(t *testing.T) {
+	s := Parse(&Legacy{}, TestDial, WithNamer(NamingStrategy{}))
+
+	if s.Name != "tbl_legacy" {
+		t.Errorf("Expected schema name 'tbl_legacy', got '%s'", s.Name)
+	}
+	if f := s.GetField("legacy_name"); f == nil || f.Name != "Name" {
+		t.Errorf("Expected column legacy_name to map to field Name, got %v", f)
+	}
+	if f := s.LookUpField("ID"); f == nil || f.Column != "id" {
+		t.Errorf("Expected field ID to map to column id, got %v", f)
+	}
+}
@@ -1,72 +1,460 @@
 package schema
 
 import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
 	"go/ast"
 	"reflect"
+	"time"
 	"tsorm/dialect"
 )
 
 // Field represents a field in a database schema.
 type Field struct {
-	Name string // Name of the field
-	Type string // Type of the field
-	Tag  string // Tag of the field
+	Name          string     // Name of the Go struct field
+	Column        string     // Column is the name of the column in the table
+	Type          string     // Type of the field
+	Tag           string     // Tag of the field
+	Nullable      bool       // Nullable reports whether the column accepts NULL, for pointer and sql.Null* fields
+	PrimaryKey    bool       // PrimaryKey reports whether the column is part of the primary key
+	AutoIncrement bool       // AutoIncrement reports whether the database generates the column value
+	Size          int        // Size is the declared column size, 0 if none
+	NotNull       bool       // NotNull reports whether the column was declared NOT NULL
+	Unique        bool       // Unique reports whether the column values must be unique
+	Default       *string    // Default is the default value expression, nil if none
+	Serializer    Serializer // Serializer encodes the field into a single column, nil if the field is stored as is
+	Version       bool       // Version reports whether the field is the version number used for optimistic locking
+	Index         []int      // Index is the index sequence of the field for reflect.Value.FieldByIndex
+
+	AutoCreateTime TimeType // AutoCreateTime is set when Insert fills the field with the current time
+	AutoUpdateTime TimeType // AutoUpdateTime is set when Insert, Update and Save fill the field with the current time
+
+	goType         reflect.Type // goType is the Go type of the struct field
+	embedded       bool         // embedded reports whether the field is a struct flattened into the schema
+	embeddedPrefix string       // embeddedPrefix is prepended to the columns of an embedded struct
+	required       bool         // required reports whether the field must not be zero
+	rules          []rule       // rules are the validation rules of the field, other than required
+}
+
+// TimeType is how a field filled with the current time stores it.
+type TimeType int
+
+const (
+	// TimeValue stores the time as a time.Time.
+	TimeValue TimeType = iota + 1
+	// UnixSecond stores the time as the number of seconds since the Unix epoch.
+	UnixSecond
+	// UnixMillisecond stores the time as the number of milliseconds since the Unix epoch.
+	UnixMillisecond
+)
+
+// ValueOf returns the value of the field in record, a struct value of the model type.
+// It returns the zero value of the field when an embedded pointer on the way is nil.
+func (f *Field) ValueOf(record reflect.Value) reflect.Value {
+	v, err := record.FieldByIndexErr(f.Index)
+	if err != nil {
+		return reflect.Zero(f.goType)
+	}
+	return v
+}
+
+// TimeOf returns now as a value of the field's Go type, stored the way the field's TimeType says.
+// AutoCreateTime takes precedence over AutoUpdateTime.
+func (f *Field) TimeOf(now time.Time) reflect.Value {
+	timeType := f.AutoCreateTime
+	if timeType == 0 {
+		timeType = f.AutoUpdateTime
+	}
+
+	var v reflect.Value
+	switch timeType {
+	case UnixSecond:
+		v = reflect.ValueOf(now.Unix())
+	case UnixMillisecond:
+		v = reflect.ValueOf(now.UnixMilli())
+	default:
+		v = reflect.ValueOf(now)
+	}
+	// Convert to the field type, allocating pointer fields.
+	if f.goType.Kind() == reflect.Ptr {
+		ptr := reflect.New(f.goType.Elem())
+		ptr.Elem().Set(v.Convert(f.goType.Elem()))
+		return ptr
+	}
+	return v.Convert(f.goType)
+}
+
+// ColumnValue returns the value of the field in record as passed to the database,
+// which encodes it with the field's serializer if it has one.
+func (f *Field) ColumnValue(record reflect.Value) interface{} {
+	return f.Encode(f.ValueOf(record).Interface())
+}
+
+// Encode returns v, a value of the field, as passed to the database,
+// which wraps it with the field's serializer if it has one.
+func (f *Field) Encode(v interface{}) interface{} {
+	if f.Serializer != nil {
+		return serializedValue{serializer: f.Serializer, value: v}
+	}
+	return v
+}
+
+// ScanDest returns the destination for scanning the column of the field in record,
+// an addressable struct value of the model type. Serialized fields are decoded by their serializer.
+func (f *Field) ScanDest(record reflect.Value) interface{} {
+	dest := f.AddrOf(record)
+	if f.Serializer != nil {
+		return serializedDest{serializer: f.Serializer, dest: dest}
+	}
+	return dest
+}
+
+// AddrOf returns a pointer to the field in record, an addressable struct value of the model type.
+// Nil embedded pointers on the way are allocated.
+func (f *Field) AddrOf(record reflect.Value) interface{} {
+	v := record
+	for i, x := range f.Index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v.Addr().Interface()
 }
 
 // Schema represents the schema of a database table.
 type Schema struct {
-	Model      interface{}       // Model is the struct type used to define the schema
-	Name       string            // Name is the name of the table
-	Fields     []*Field          // Fields is a slice of fields in the table
-	FieldNames []string          // FieldNames is a slice of field names in the table
-	fieldMap   map[string]*Field // fieldMap is a map of field names to Field objects
+	Model         interface{}       // Model is the struct type used to define the schema
+	Name          string            // Name is the name of the table
+	Fields        []*Field          // Fields is a slice of fields in the table
+	FieldNames    []string          // FieldNames is a slice of column names in the table
+	PrimaryFields []*Field          // PrimaryFields are the fields of the primary key, in declaration order
+	AutoIncrement *Field            // AutoIncrement is the auto-increment field, nil if there is none
+	Version       *Field            // Version is the version field used for optimistic locking, nil if there is none
+	DeletedAt     *Field            // DeletedAt is the *time.Time or sql.NullTime DeletedAt field marking soft-deleted records, nil if there is none
+	fieldMap      map[string]*Field // fieldMap is a map of column names to Field objects
 }
 
-// GetField returns the field with the given name from the schema.
+// GetField returns the field with the given column name from the schema.
 func (s *Schema) GetField(name string) *Field {
 	return s.fieldMap[name]
 }
 
+// LookUpField returns the field with the given column name or, failing that, Go field name.
+func (s *Schema) LookUpField(name string) *Field {
+	if field, ok := s.fieldMap[name]; ok {
+		return field
+	}
+	for _, field := range s.Fields {
+		if field.Name == name {
+			return field
+		}
+	}
+	return nil
+}
+
+// options holds the settings applied by Parse.
+type options struct {
+	namer Namer // namer maps Go names to table and column names
+}
+
+// Option configures how Parse maps a model to a schema.
+type Option func(*options)
+
+// WithNamer sets the Namer used to derive table and column names.
+func WithNamer(namer Namer) Option {
+	return func(o *options) {
+		if namer != nil {
+			o.namer = namer
+		}
+	}
+}
+
 // Parse parses the schema for the given model using the specified dialect.
-func Parse(dest interface{}, d dialect.Dialect) *Schema {
-	modeType := reflect.Indirect(reflect.ValueOf(dest)).Type()
+// It panics if the model cannot be parsed; use ParseE to get an error instead.
+func Parse(dest interface{}, d dialect.Dialect, opts ...Option) *Schema {
+	schema, err := ParseE(dest, d, opts...)
+	if err != nil {
+		panic(err)
+	}
+	return schema
+}
+
+// ParseE parses the schema for the given model using the specified dialect.
+// It returns an error if dest is not a struct or pointer to a struct, if a field has an invalid tsorm tag,
+// or if the dialect has no SQL type for a field.
+func ParseE(dest interface{}, d dialect.Dialect, opts ...Option) (*Schema, error) {
+	return parse(dest, d, opts)
+}
+
+// parse parses the schema for the given model, returning an error if it cannot be parsed.
+func parse(dest interface{}, d dialect.Dialect, opts []Option) (*Schema, error) {
+	o := options{namer: identityNamer{}}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	modeType, err := modelType(dest)
+	if err != nil {
+		return nil, err
+	}
 	schema := &Schema{
 		Model:    dest,
-		Name:     modeType.Name(),
+		Name:     o.namer.TableName(modeType.Name()),
 		fieldMap: make(map[string]*Field),
 	}
+	// Models implementing Tabler choose their own table name.
+	if tabler, ok := reflect.New(modeType).Interface().(Tabler); ok {
+		schema.Name = tabler.TableName()
+	}
+
+	// Iterate over the fields of the model type, flattening embedded structs.
+	if err := schema.parseFields(modeType, nil, "", d, &o); err != nil {
+		return nil, err
+	}
 
-	// Iterate over the fields of the model type.
-	for i := 0; i < modeType.NumField(); i++ {
-		p := modeType.Field(i)
-		// Check if the field is exported and not anonymous.
-		if !p.Anonymous && ast.IsExported(p.Name) {
-			// Create a Field object for the field.
-			field := &Field{
-				Name: p.Name,
-				Type: d.DataTypeOf(reflect.Indirect(reflect.New(p.Type))),
+	// Collect the primary key, which defaults to a field named ID by convention.
+	for _, field := range schema.Fields {
+		if field.PrimaryKey {
+			schema.PrimaryFields = append(schema.PrimaryFields, field)
+		}
+		if field.AutoIncrement && schema.AutoIncrement == nil {
+			schema.AutoIncrement = field
+		}
+		if field.Version && schema.Version == nil {
+			schema.Version = field
+		}
+		// A *time.Time or sql.NullTime DeletedAt field turns deletes into soft deletes.
+		if field.Name == "DeletedAt" && isNullTime(field.goType) {
+			schema.DeletedAt = field
+		}
+	}
+	// Without a primaryKey tag, an ID field is the primary key, generated by the database if it is an integer.
+	if len(schema.PrimaryFields) == 0 {
+		field := schema.LookUpField("ID")
+		if field == nil {
+			field = schema.LookUpField("Id")
+		}
+		if field != nil {
+			field.PrimaryKey = true
+			schema.PrimaryFields = []*Field{field}
+			if isInteger(field.goType) && schema.AutoIncrement == nil {
+				field.AutoIncrement = true
+				schema.AutoIncrement = field
 			}
-			// Check if the field has a "tsorm" tag.
-			if v, ok := p.Tag.Lookup("tsorm"); ok {
-				field.Tag = v
+		}
+	}
+	if schema.AutoIncrement != nil && len(schema.PrimaryFields) > 1 && !d.CompositeAutoIncrement() {
+		return nil, fmt.Errorf("schema: field %s.%s: dialect %s cannot auto-increment a column of a composite primary key",
+			modeType.Name(), schema.AutoIncrement.Name, dialect.NameOf(d))
+	}
+	return schema, nil
+}
+
+// modelType returns the struct type of dest, a struct or pointer to a struct.
+func modelType(dest interface{}) (reflect.Type, error) {
+	if dest == nil {
+		return nil, errors.New("schema: model is nil")
+	}
+	t := reflect.TypeOf(dest)
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("schema: model %T is not a struct or pointer to a struct", dest)
+	}
+	return t, nil
+}
+
+// parseFields adds the fields of the struct type typ, found at the index sequence index of
+// the model, to the schema. Embedded structs are flattened recursively with their column prefix.
+func (s *Schema) parseFields(typ reflect.Type, index []int, prefix string, d dialect.Dialect, o *options) (err error) {
+	// Iterate over the fields of the struct type.
+	for i := 0; i < typ.NumField(); i++ {
+		p := typ.Field(i)
+		// Skip fields ignored with "-" and unexported fields, except embedded struct values.
+		tag, hasTag := p.Tag.Lookup("tsorm")
+		if tag == "-" || (!ast.IsExported(p.Name) && (!p.Anonymous || p.Type.Kind() == reflect.Ptr)) {
+			continue
+		}
+
+		// Create a Field object for the field.
+		field := &Field{
+			Name:   p.Name,
+			Column: o.namer.ColumnName(p.Name),
+			Index:  append(append([]int(nil), index...), i),
+			goType: p.Type,
+		}
+		// Apply the settings of the "tsorm" tag, which may override the column name and type.
+		if hasTag {
+			field.Tag = tag
+			if err := parseTag(field, tag); err != nil {
+				return fmt.Errorf("schema: field %s.%s: %w", typ.Name(), p.Name, err)
+			}
+		}
+
+		// Pointer fields are nullable columns of the pointed-to type.
+		fieldType := p.Type
+		if fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+			field.Nullable = true
+		}
+
+		// Flatten anonymous and "embedded"-tagged structs into the schema.
+		if (p.Anonymous || field.embedded) && isEmbeddable(fieldType) {
+			if err := s.parseFields(fieldType, field.Index, prefix+field.embeddedPrefix, d, o); err != nil {
+				return err
 			}
-			// Add the field to the schema's Fields and fieldMap.
-			schema.Fields = append(schema.Fields, field)
-			schema.FieldNames = append(schema.FieldNames, p.Name)
-			schema.fieldMap[p.Name] = field
+			continue
+		}
+		if p.Anonymous || !ast.IsExported(p.Name) {
+			continue
+		}
+
+		field.Column = prefix + field.Column
+		field.Nullable = field.Nullable || isNullType(fieldType)
+		// Serialized slices and maps store nil as NULL.
+		if field.Serializer != nil && (fieldType.Kind() == reflect.Slice || fieldType.Kind() == reflect.Map) {
+			field.Nullable = true
 		}
+		if field.Version && !isInteger(p.Type) {
+			return fmt.Errorf("schema: field %s.%s: version requires an integer field, got %s", typ.Name(), p.Name, p.Type)
+		}
+		if err := checkRules(field, fieldType); err != nil {
+			return fmt.Errorf("schema: field %s.%s: %w", typ.Name(), p.Name, err)
+		}
+		if err := resolveAutoTime(field, fieldType); err != nil {
+			return fmt.Errorf("schema: field %s.%s: %w", typ.Name(), p.Name, err)
+		}
+		// Derive the type from the dialect unless the tag set it explicitly.
+		// Serialized fields take the type of their encoded value.
+		if field.Type == "" {
+			v := reflect.New(fieldType).Elem()
+			if field.Serializer != nil {
+				v = serializedType(field.Serializer, p.Type)
+			}
+			if field.Type, err = dataTypeOf(d, v, field.Size); err != nil {
+				return fmt.Errorf("schema: field %s.%s: %w", typ.Name(), p.Name, err)
+			}
+		}
+		if _, ok := s.fieldMap[field.Column]; ok {
+			return fmt.Errorf("schema: field %s.%s: duplicate column %q", typ.Name(), p.Name, field.Column)
+		}
+
+		// Add the field to the schema's Fields and fieldMap.
+		s.Fields = append(s.Fields, field)
+		s.FieldNames = append(s.FieldNames, field.Column)
+		s.fieldMap[field.Column] = field
 	}
-	return schema
+	return nil
+}
+
+// isEmbeddable reports whether t is a struct whose fields can be flattened into a schema,
+// as opposed to a struct stored in a single column such as time.Time or sql.NullString.
+func isEmbeddable(t reflect.Type) bool {
+	if t.Kind() != reflect.Struct || t == reflect.TypeOf(time.Time{}) {
+		return false
+	}
+	return !reflect.PointerTo(t).Implements(scannerType) && !reflect.PointerTo(t).Implements(valuerType)
+}
+
+// resolveAutoTime sets how the automatic timestamps of field, whose type is t, store the time.
+// Fields named CreatedAt and UpdatedAt are automatic timestamps by convention.
+func resolveAutoTime(field *Field, t reflect.Type) error {
+	isTime := t == reflect.TypeOf(time.Time{})
+	isInt := isInteger(t)
+	if !isTime && !isInt {
+		if field.AutoCreateTime != 0 || field.AutoUpdateTime != 0 {
+			return fmt.Errorf("automatic timestamp requires a time.Time or integer field, got %s", t)
+		}
+		return nil
+	}
+
+	// Apply the naming convention unless the tag set the timestamp explicitly.
+	if field.Name == "CreatedAt" && field.AutoCreateTime == 0 {
+		field.AutoCreateTime = UnixSecond
+	}
+	if field.Name == "UpdatedAt" && field.AutoUpdateTime == 0 {
+		field.AutoUpdateTime = UnixSecond
+	}
+	// time.Time fields always store the time itself.
+	if isTime {
+		if field.AutoCreateTime != 0 {
+			field.AutoCreateTime = TimeValue
+		}
+		if field.AutoUpdateTime != 0 {
+			field.AutoUpdateTime = TimeValue
+		}
+	}
+	return nil
+}
+
+// isNullTime reports whether t is *time.Time or sql.NullTime, the types of a nullable time column.
+func isNullTime(t reflect.Type) bool {
+	return t == reflect.TypeOf((*time.Time)(nil)) || t == reflect.TypeOf(sql.NullTime{})
+}
+
+// isInteger reports whether t is an integer type wide enough to hold a timestamp or version number.
+func isInteger(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int32, reflect.Int64, reflect.Uint, reflect.Uint32, reflect.Uint64:
+		return true
+	}
+	return false
+}
+
+// dataTypeOf returns the SQL type of v, taking the declared size into account when there is one.
+// It returns an error if the dialect has no SQL type for v.
+func dataTypeOf(d dialect.Dialect, v reflect.Value, size int) (typ string, err error) {
+	// Dialects panic on types they do not support.
+	defer func() {
+		if recover() != nil {
+			err = fmt.Errorf("unsupported Go type %s for dialect %s", v.Type(), dialect.NameOf(d))
+		}
+	}()
+	if size > 0 {
+		return d.SizedDataTypeOf(v, size), nil
+	}
+	return d.DataTypeOf(v), nil
 }
 
 // RecordValues extracts field values from a record and returns them as a slice of interfaces.
-func (s *Schema) RecordValues(dest interface{}) []interface{} {
+// If columns are given, only the values of those columns are returned, in that order.
+func (s *Schema) RecordValues(dest interface{}, columns ...string) []interface{} {
 	destValue := reflect.Indirect(reflect.ValueOf(dest))
+	fields := s.Fields
+	if len(columns) > 0 {
+		fields = make([]*Field, 0, len(columns))
+		for _, column := range columns {
+			fields = append(fields, s.fieldMap[column])
+		}
+	}
+
 	var fieldValues []interface{}
 	// Iterate over the fields of the schema.
-	for _, field := range s.Fields {
+	for _, field := range fields {
 		// Get the value of the field from the record.
-		fieldValues = append(fieldValues, destValue.FieldByName(field.Name).Interface())
+		fieldValues = append(fieldValues, field.ColumnValue(destValue))
 	}
 	return fieldValues
 }
+
+// scannerType and valuerType are the types of the sql.Scanner and driver.Valuer interfaces.
+var (
+	scannerType = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+	valuerType  = reflect.TypeOf((*driver.Valuer)(nil)).Elem()
+)
+
+// isNullType reports whether t is a sql.Null*-like type: a sql.Scanner struct with a bool Valid field.
+func isNullType(t reflect.Type) bool {
+	if t.Kind() != reflect.Struct || !reflect.PointerTo(t).Implements(scannerType) {
+		return false
+	}
+	valid, ok := t.FieldByName("Valid")
+	return ok && valid.Type.Kind() == reflect.Bool
+}
@@ -1,8 +1,11 @@
 package schema
 
 import (
+	"database/sql"
+	"errors"
 	"reflect"
 	"testing"
+	"time"
 	"tsorm/dialect"
 )
 
@@ -82,3 +85,382 @@ func TestRecordValues(t *testing.T) {
 		}
 	}
 }
+
+// prefixNamer is a Namer that prefixes table and column names.
+type prefixNamer struct{}
+
+func (prefixNamer) TableName(structName string) string { return "t_" + structName }
+func (prefixNamer) ColumnName(fieldName string) string { return "c_" + fieldName }
+
+// TestParseWithNamer tests that Parse maps names through the given Namer.
+func TestParseWithNamer(t *testing.T) {
+	s := Parse(&User{}, TestDial, WithNamer(prefixNamer{}))
+
+	if s.Name != "t_User" {
+		t.Errorf("Expected schema name 't_User', got '%s'", s.Name)
+	}
+	if !reflect.DeepEqual(s.FieldNames, []string{"c_ID", "c_Name", "c_Age"}) {
+		t.Errorf("Unexpected column names %v", s.FieldNames)
+	}
+	if f := s.GetField("c_Name"); f == nil || f.Name != "Name" {
+		t.Errorf("Expected column c_Name to map to field Name, got %v", f)
+	}
+}
+
+// Document is a model with a field whose SQL type is given explicitly.
+type Document struct {
+	Title string            `tsorm:"NOT NULL"`
+	Body  map[string]string `tsorm:"type:json;NOT NULL"`
+}
+
+// TestParseTypeOverride tests that the "type:" tag setting overrides the dialect type.
+func TestParseTypeOverride(t *testing.T) {
+	s := Parse(&Document{}, TestDial)
+
+	body := s.GetField("Body")
+	if body.Type != "json" || !body.NotNull {
+		t.Errorf("Expected type json and NOT NULL, got %s and %v", body.Type, body.NotNull)
+	}
+}
+
+// Nullable is a model mixing nullable and non-nullable fields.
+type Nullable struct {
+	Name  string
+	Age   *int
+	Email sql.NullString
+}
+
+// TestParseNullable tests that pointer and sql.Null* fields are nullable.
+func TestParseNullable(t *testing.T) {
+	s := Parse(&Nullable{}, TestDial)
+
+	expected := map[string]struct {
+		Type     string
+		Nullable bool
+	}{
+		"Name":  {"text", false},
+		"Age":   {"integer", true},
+		"Email": {"text", true},
+	}
+	for _, field := range s.Fields {
+		if want := expected[field.Name]; field.Type != want.Type || field.Nullable != want.Nullable {
+			t.Errorf("Expected %v for field %s, got %s %v", want, field.Name, field.Type, field.Nullable)
+		}
+	}
+}
+
+// Product is a model using every structured tag setting.
+type Product struct {
+	ID       int64  `tsorm:"primaryKey;autoIncrement"`
+	Code     string `tsorm:"column:sku;size:32;unique;notNull"`
+	Price    int    `tsorm:"default:0"`
+	Note     string `tsorm:"type:varchar(10)"`
+	Internal string `tsorm:"-"`
+}
+
+// TestParseTagSettings tests that tsorm tags are parsed into typed attributes.
+func TestParseTagSettings(t *testing.T) {
+	s := Parse(&Product{}, TestDial)
+
+	if !reflect.DeepEqual(s.FieldNames, []string{"ID", "sku", "Price", "Note"}) {
+		t.Fatalf("Unexpected columns %v", s.FieldNames)
+	}
+	id, code, price, note := s.Fields[0], s.Fields[1], s.Fields[2], s.Fields[3]
+	if !id.PrimaryKey || !id.AutoIncrement {
+		t.Errorf("Expected auto-increment primary key, got %+v", id)
+	}
+	if code.Size != 32 || !code.Unique || !code.NotNull || code.Name != "Code" {
+		t.Errorf("Unexpected attributes %+v", code)
+	}
+	if price.Default == nil || *price.Default != "0" {
+		t.Errorf("Expected default 0, got %v", price.Default)
+	}
+	if note.Type != "varchar(10)" {
+		t.Errorf("Expected type varchar(10), got %s", note.Type)
+	}
+}
+
+// TestParseUnknownTag tests that unknown tag settings are rejected with a clear error.
+func TestParseUnknownTag(t *testing.T) {
+	type Broken struct {
+		Name string `tsorm:"primaryKey;indexed"`
+	}
+	defer func() {
+		err, ok := recover().(error)
+		if !ok || err.Error() != `schema: field Broken.Name: unknown tag setting "indexed"` {
+			t.Errorf("Unexpected panic %v", err)
+		}
+	}()
+	Parse(&Broken{}, TestDial)
+}
+
+// Event has automatic timestamps set by convention and by tag.
+type Event struct {
+	ID        int
+	CreatedAt time.Time
+	UpdatedAt *time.Time
+	Created   int64 `tsorm:"autoCreateTime"`
+	Updated   int64 `tsorm:"autoUpdateTime:milli"`
+}
+
+// TestParseDeletedAt tests that only time-typed DeletedAt fields enable soft delete.
+func TestParseDeletedAt(t *testing.T) {
+	type Timed struct {
+		DeletedAt *time.Time
+	}
+	type NullTimed struct {
+		DeletedAt sql.NullTime
+	}
+	type Unix struct {
+		DeletedAt *int64
+	}
+	for _, tc := range []struct {
+		model    interface{}
+		expected bool
+	}{{&Timed{}, true}, {&NullTimed{}, true}, {&Unix{}, false}} {
+		if got := Parse(tc.model, TestDial).DeletedAt != nil; got != tc.expected {
+			t.Errorf("%T: got soft delete %v, want %v", tc.model, got, tc.expected)
+		}
+	}
+}
+
+// TestParseAutoTime tests that automatic timestamp fields are recognised with the right storage.
+func TestParseAutoTime(t *testing.T) {
+	schema := Parse(&Event{}, TestDial)
+	testCases := []struct {
+		column         string
+		create, update TimeType
+	}{
+		{"ID", 0, 0},
+		{"CreatedAt", TimeValue, 0},
+		{"UpdatedAt", 0, TimeValue},
+		{"Created", UnixSecond, 0},
+		{"Updated", 0, UnixMillisecond},
+	}
+	for _, tc := range testCases {
+		field := schema.GetField(tc.column)
+		if field.AutoCreateTime != tc.create || field.AutoUpdateTime != tc.update {
+			t.Errorf("%s: got %d/%d, want %d/%d", tc.column, field.AutoCreateTime, field.AutoUpdateTime, tc.create, tc.update)
+		}
+	}
+
+	now := time.UnixMilli(1700000000123)
+	if v := schema.GetField("Updated").TimeOf(now).Interface(); v != int64(1700000000123) {
+		t.Errorf("failed to convert to milliseconds, got %v", v)
+	}
+	if v := schema.GetField("UpdatedAt").TimeOf(now).Interface().(*time.Time); !v.Equal(now) {
+		t.Errorf("failed to convert to *time.Time, got %v", v)
+	}
+}
+
+// Preferences holds settings stored in serialized columns.
+type Preferences struct {
+	ID       int
+	Theme    map[string]string     `tsorm:"serializer:json"`
+	Layout   struct{ Columns int } `tsorm:"serializer:gob"`
+	Optional *[]string             `tsorm:"serializer:json"`
+}
+
+// TestParseSerializer tests that serialized fields take the column type of their encoded value.
+func TestParseSerializer(t *testing.T) {
+	schema := Parse(&Preferences{}, TestDial)
+	for column, typ := range map[string]string{"Theme": "text", "Layout": "blob", "Optional": "text"} {
+		if field := schema.GetField(column); field.Serializer == nil || field.Type != typ {
+			t.Errorf("%s: got type %s, want %s", column, field.Type, typ)
+		}
+	}
+	// Serialized maps and pointers are nullable, structs are not.
+	for column, nullable := range map[string]bool{"Theme": true, "Layout": false, "Optional": true} {
+		if field := schema.GetField(column); field.Nullable != nullable {
+			t.Errorf("%s: got nullable %v, want %v", column, field.Nullable, nullable)
+		}
+	}
+	if _, err := parse(&struct {
+		Data map[string]int `tsorm:"serializer:xml"`
+	}{}, TestDial, nil); err == nil {
+		t.Error("expected an error for an unknown serializer")
+	}
+}
+
+// Signup is a model with validation rules.
+type Signup struct {
+	Name  string  `tsorm:"required;len:4"`
+	Age   int     `tsorm:"min:18;max:130"`
+	Code  string  `tsorm:"regex:^[A-Z]{3}$"`
+	Plan  string  `tsorm:"oneof:free pro"`
+	Email *string `tsorm:"required"`
+}
+
+// Validate rejects the reserved name.
+func (s *Signup) Validate() error {
+	if s.Name == "root" {
+		return errors.New("name is reserved")
+	}
+	return nil
+}
+
+// TestValidate tests that every failing rule is reported.
+func TestValidate(t *testing.T) {
+	schema := Parse(&Signup{}, TestDial)
+	email := "tom@example.com"
+	if err := schema.Validate(&Signup{Name: "Tomy", Age: 30, Code: "ABC", Plan: "pro", Email: &email}); err != nil {
+		t.Fatal("expected a valid record, got", err)
+	}
+
+	err := schema.Validate(&Signup{Name: "root", Age: 12, Code: "abc", Plan: "gold"})
+	var errs ValidationErrors
+	if !errors.As(err, &errs) {
+		t.Fatal("expected ValidationErrors, got", err)
+	}
+	var got []string
+	for _, e := range errs {
+		got = append(got, e.Field+":"+e.Rule)
+	}
+	expected := []string{"Age:min", "Code:regex", "Plan:oneof", "Email:required", ":validate"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Fatalf("got %v, want %v", got, expected)
+	}
+
+	if err := schema.ValidateColumns(map[string]interface{}{"Age": 200}); err == nil {
+		t.Fatal("expected an error for an invalid column value")
+	}
+	if _, err := parse(&struct {
+		Count int `tsorm:"regex:^1$"`
+	}{}, TestDial, nil); err == nil {
+		t.Fatal("expected an error for regex on an int field")
+	}
+}
+
+// TestParseE tests that models which cannot be parsed return descriptive errors.
+func TestParseE(t *testing.T) {
+	type Config struct {
+		ID      int
+		Options map[string]string
+	}
+	testCases := []struct {
+		model    interface{}
+		expected string
+	}{
+		{nil, "schema: model is nil"},
+		{42, "schema: model int is not a struct or pointer to a struct"},
+		{&Config{}, "schema: field Config.Options: unsupported Go type map[string]string for dialect sqlite3"},
+	}
+	for _, tc := range testCases {
+		if _, err := ParseE(tc.model, TestDial); err == nil || err.Error() != tc.expected {
+			t.Errorf("got %v, want %s", err, tc.expected)
+		}
+	}
+	if _, err := ParseCachedE(&Config{}, TestDial); err == nil {
+		t.Error("expected ParseCachedE to return an error")
+	}
+}
+
+// BaseModel holds the columns shared by every entity.
+type BaseModel struct {
+	ID      int `tsorm:"primaryKey"`
+	Created int64
+}
+
+// Author is a struct embedded with a column prefix.
+type Author struct {
+	Name  string
+	Email string
+}
+
+// Article is a model flattening embedded structs.
+type Article struct {
+	*BaseModel
+	Title  string
+	Author Author `tsorm:"embeddedPrefix:author_"`
+}
+
+// TestParseEmbedded tests that embedded structs are flattened into the schema.
+func TestParseEmbedded(t *testing.T) {
+	s := Parse(&Article{}, TestDial)
+
+	expectedColumns := []string{"ID", "Created", "Title", "author_Name", "author_Email"}
+	if !reflect.DeepEqual(s.FieldNames, expectedColumns) {
+		t.Fatalf("Expected columns %v, got %v", expectedColumns, s.FieldNames)
+	}
+	if !s.GetField("ID").PrimaryKey {
+		t.Errorf("Expected the tags of embedded fields to be parsed")
+	}
+
+	// Reading through a nil embedded pointer yields zero values.
+	article := Article{Title: "Go", Author: Author{Name: "Rob", Email: "rob@go"}}
+	expectedValues := []interface{}{0, int64(0), "Go", "Rob", "rob@go"}
+	if values := s.RecordValues(&article); !reflect.DeepEqual(values, expectedValues) {
+		t.Errorf("Expected record values %v, got %v", expectedValues, values)
+	}
+
+	// Writing through a nil embedded pointer allocates it.
+	*s.GetField("ID").AddrOf(reflect.ValueOf(&article).Elem()).(*int) = 7
+	if article.BaseModel == nil || article.ID != 7 {
+		t.Errorf("Expected the embedded pointer to be allocated, got %v", article.BaseModel)
+	}
+}
+
+// TestParseCached tests that ParseCached parses each model type once per dialect and namer.
+func TestParseCached(t *testing.T) {
+	s1 := ParseCached(&User{}, TestDial)
+	s2 := ParseCached(User{}, TestDial)
+	if s1 != s2 {
+		t.Error("Expected the cached schema to be reused")
+	}
+	if s1.Model == interface{}(&User{}) || reflect.TypeOf(s1.Model) != reflect.TypeOf(&User{}) {
+		t.Errorf("Expected the model of the cached schema to be a new *User, got %T", s1.Model)
+	}
+	if s3 := ParseCached(&User{}, TestDial, WithNamer(NamingStrategy{})); s3 == s1 || s3.Name != "users" {
+		t.Error("Expected a separate schema for another namer")
+	}
+}
+
+// BenchmarkParse measures parsing a schema without the cache.
+func BenchmarkParse(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		Parse(&User{}, TestDial)
+	}
+}
+
+// BenchmarkParseCached measures looking up a cached schema.
+func BenchmarkParseCached(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		ParseCached(&User{}, TestDial)
+	}
+}
+
+// TestParsePrimaryFields tests the primary key from tags and from the ID convention.
+func TestParsePrimaryFields(t *testing.T) {
+	type Conventional struct {
+		Name string
+		ID   int
+	}
+	if s := Parse(&Conventional{}, TestDial); len(s.PrimaryFields) != 1 || s.PrimaryFields[0].Name != "ID" || s.AutoIncrement != s.PrimaryFields[0] {
+		t.Errorf("Expected ID to be the auto-increment primary key, got %v", s.PrimaryFields)
+	}
+	type Coded struct {
+		ID string
+	}
+	if s := Parse(&Coded{}, TestDial); len(s.PrimaryFields) != 1 || s.AutoIncrement != nil {
+		t.Errorf("Expected string ID to be a primary key without auto-increment, got %v", s.AutoIncrement)
+	}
+	if s := Parse(&User{}, TestDial); len(s.PrimaryFields) != 1 || s.PrimaryFields[0].Name != "ID" {
+		t.Errorf("Expected the tagged ID to be the primary key, got %v", s.PrimaryFields)
+	}
+}
+
+// TestParseCompositeAutoIncrement tests that auto-increment columns of composite keys need dialect support.
+func TestParseCompositeAutoIncrement(t *testing.T) {
+	type LineItem struct {
+		OrderID int   `tsorm:"primaryKey"`
+		ID      int64 `tsorm:"primaryKey;autoIncrement"`
+	}
+	expected := "schema: field LineItem.ID: dialect sqlite3 cannot auto-increment a column of a composite primary key"
+	if _, err := ParseE(&LineItem{}, TestDial); err == nil || err.Error() != expected {
+		t.Errorf("Expected %q, got %v", expected, err)
+	}
+	mysql, _ := dialect.GetDialect("mysql")
+	if _, err := ParseE(&LineItem{}, mysql); err != nil {
+		t.Errorf("Unexpected error %v", err)
+	}
+}
@@ -0,0 +1,125 @@
+package schema
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// Serializer encodes field values into column values and decodes them back.
+// It lets fields of types without a SQL type, such as maps and structs, be stored in a single column.
+type Serializer interface {
+	// Marshal encodes v, the value of the field, into the value stored in the column, a string or []byte.
+	Marshal(v interface{}) (interface{}, error)
+	// Unmarshal decodes the column value data into v, a pointer to the field.
+	Unmarshal(data []byte, v interface{}) error
+}
+
+// serializers holds the serializers which can be selected with the "serializer" tag setting.
+var serializers = struct {
+	sync.RWMutex
+	m map[string]Serializer
+}{m: map[string]Serializer{
+	"json": JSONSerializer{},
+	"gob":  GobSerializer{},
+}}
+
+// RegisterSerializer makes a serializer available to the "serializer:<name>" tag setting.
+func RegisterSerializer(name string, s Serializer) {
+	serializers.Lock()
+	defer serializers.Unlock()
+	serializers.m[name] = s
+}
+
+// GetSerializer returns the serializer registered under name.
+func GetSerializer(name string) (s Serializer, ok bool) {
+	serializers.RLock()
+	defer serializers.RUnlock()
+	s, ok = serializers.m[name]
+	return
+}
+
+// JSONSerializer stores values as JSON text.
+type JSONSerializer struct{}
+
+// Marshal encodes v as a JSON string.
+func (JSONSerializer) Marshal(v interface{}) (interface{}, error) {
+	data, err := json.Marshal(v)
+	return string(data), err
+}
+
+// Unmarshal decodes the JSON data into v.
+func (JSONSerializer) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+// GobSerializer stores values as gob-encoded bytes.
+type GobSerializer struct{}
+
+// Marshal encodes v with encoding/gob.
+func (GobSerializer) Marshal(v interface{}) (interface{}, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Unmarshal decodes the gob data into v.
+func (GobSerializer) Unmarshal(data []byte, v interface{}) error {
+	return gob.NewDecoder(bytes.NewReader(data)).Decode(v)
+}
+
+// serializedValue encodes a field value with its serializer when the driver asks for it.
+type serializedValue struct {
+	serializer Serializer
+	value      interface{}
+}
+
+// Value implements driver.Valuer. Nil values, pointers, slices and maps are stored as NULL.
+func (v serializedValue) Value() (driver.Value, error) {
+	if v.value == nil {
+		return nil, nil
+	}
+	switch rv := reflect.ValueOf(v.value); rv.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Map, reflect.Interface:
+		if rv.IsNil() {
+			return nil, nil
+		}
+	}
+	return v.serializer.Marshal(v.value)
+}
+
+// serializedDest decodes a column value into a field with its serializer.
+type serializedDest struct {
+	serializer Serializer
+	dest       interface{}
+}
+
+// Scan implements sql.Scanner. NULL leaves the field unchanged.
+func (d serializedDest) Scan(src interface{}) error {
+	switch data := src.(type) {
+	case nil:
+		return nil
+	case []byte:
+		return d.serializer.Unmarshal(data, d.dest)
+	case string:
+		return d.serializer.Unmarshal([]byte(data), d.dest)
+	default:
+		return fmt.Errorf("schema: cannot deserialize %T", src)
+	}
+}
+
+// serializedType returns a value of the type stored by s for values of type t,
+// found by encoding the zero value. It falls back to []byte if that fails.
+func serializedType(s Serializer, t reflect.Type) reflect.Value {
+	v, err := s.Marshal(reflect.Zero(t).Interface())
+	if err != nil || v == nil {
+		return reflect.ValueOf([]byte(nil))
+	}
+	return reflect.ValueOf(v)
+}
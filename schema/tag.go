@@ -0,0 +1,99 @@
+package schema
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// normalizeTagKey upper-cases a tag key and strips spaces and underscores, so that
+// "primaryKey", "primary_key" and "PRIMARY KEY" are the same setting.
+func normalizeTagKey(key string) string {
+	return strings.NewReplacer(" ", "", "_", "").Replace(strings.ToUpper(strings.TrimSpace(key)))
+}
+
+// parseTag applies the ";"-separated "key" or "key:value" settings of a tsorm tag to field.
+// It returns an error for unknown keys and invalid values.
+func parseTag(field *Field, tag string) error {
+	for _, part := range strings.Split(tag, ";") {
+		if strings.TrimSpace(part) == "" {
+			continue
+		}
+		key, value, _ := strings.Cut(part, ":")
+		value = strings.TrimSpace(value)
+		switch normalizeTagKey(key) {
+		case "PRIMARYKEY":
+			field.PrimaryKey = true
+		case "AUTOINCREMENT":
+			// Auto-increment columns are always the primary key.
+			field.AutoIncrement, field.PrimaryKey = true, true
+		case "NOTNULL":
+			field.NotNull = true
+		case "UNIQUE":
+			field.Unique = true
+		case "COLUMN":
+			if value == "" {
+				return fmt.Errorf("tag setting %q requires a value", key)
+			}
+			field.Column = value
+		case "TYPE":
+			if value == "" {
+				return fmt.Errorf("tag setting %q requires a value", key)
+			}
+			field.Type = value
+		case "SIZE":
+			size, err := strconv.Atoi(value)
+			if err != nil || size <= 0 {
+				return fmt.Errorf("tag setting %q requires a positive integer, got %q", key, value)
+			}
+			field.Size = size
+		case "EMBEDDED":
+			field.embedded = true
+		case "EMBEDDEDPREFIX":
+			field.embedded, field.embeddedPrefix = true, value
+		case "DEFAULT":
+			if value == "" {
+				return fmt.Errorf("tag setting %q requires a value", key)
+			}
+			field.Default = &value
+		case "REQUIRED", "MIN", "MAX", "LEN", "REGEX", "ONEOF":
+			if err := field.addRule(strings.TrimSpace(key), value); err != nil {
+				return err
+			}
+		case "SERIALIZER":
+			serializer, ok := GetSerializer(value)
+			if !ok {
+				return fmt.Errorf("unknown serializer %q", value)
+			}
+			field.Serializer = serializer
+		case "VERSION":
+			field.Version = true
+		case "AUTOCREATETIME", "AUTOUPDATETIME":
+			timeType, err := parseTimeType(value)
+			if err != nil {
+				return fmt.Errorf("tag setting %q: %w", strings.TrimSpace(key), err)
+			}
+			if normalizeTagKey(key) == "AUTOCREATETIME" {
+				field.AutoCreateTime = timeType
+			} else {
+				field.AutoUpdateTime = timeType
+			}
+		default:
+			return fmt.Errorf("unknown tag setting %q", strings.TrimSpace(key))
+		}
+	}
+	return nil
+}
+
+// parseTimeType parses the value of an autoCreateTime or autoUpdateTime setting.
+// Integer fields store Unix seconds by default and Unix milliseconds with "milli".
+func parseTimeType(value string) (TimeType, error) {
+	switch strings.ToUpper(value) {
+	case "":
+		return UnixSecond, nil
+	case "MILLI":
+		return UnixMillisecond, nil
+	default:
+		return 0, fmt.Errorf("unknown time unit %q", value)
+	}
+}
@@ -0,0 +1,226 @@
+package schema
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Validator is implemented by models which check themselves before they are written.
+type Validator interface {
+	Validate() error
+}
+
+// FieldError describes a field which failed validation.
+type FieldError struct {
+	Field   string // Field is the Go name of the field, empty for errors returned by Validate
+	Rule    string // Rule is the failing tag setting, such as "min", or "validate" for Validate
+	Message string // Message describes the failure
+}
+
+// Error returns the field name followed by the message.
+func (e FieldError) Error() string {
+	if e.Field == "" {
+		return e.Message
+	}
+	return e.Field + " " + e.Message
+}
+
+// ValidationErrors lists every field of a record which failed validation.
+type ValidationErrors []FieldError
+
+// Error joins the errors of every failing field.
+func (e ValidationErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return "validation failed: " + strings.Join(msgs, "; ")
+}
+
+// rule is a validation rule declared in a tsorm tag.
+type rule struct {
+	name    string                   // name is the tag setting, such as "min"
+	message string                   // message describes the failure
+	check   func(reflect.Value) bool // check reports whether a non-nil field value is valid
+}
+
+// addRule adds the validation rule of the tag setting name with the given parameter to field.
+// As settings are separated by ";", regex patterns cannot contain it.
+func (f *Field) addRule(name, param string) error {
+	r := rule{name: strings.ToLower(normalizeTagKey(name))}
+	switch r.name {
+	case "required":
+		f.required = true
+		return nil
+	case "min", "max":
+		limit, err := strconv.ParseFloat(param, 64)
+		if err != nil {
+			return fmt.Errorf("tag setting %q requires a number, got %q", name, param)
+		}
+		if r.name == "min" {
+			r.message = "must be at least " + param
+			r.check = func(v reflect.Value) bool { n, _ := magnitude(v); return n >= limit }
+		} else {
+			r.message = "must be at most " + param
+			r.check = func(v reflect.Value) bool { n, _ := magnitude(v); return n <= limit }
+		}
+	case "len":
+		n, err := strconv.Atoi(param)
+		if err != nil || n < 0 {
+			return fmt.Errorf("tag setting %q requires a non-negative integer, got %q", name, param)
+		}
+		r.message = "must have length " + param
+		r.check = func(v reflect.Value) bool { l, _ := length(v); return l == n }
+	case "regex":
+		re, err := regexp.Compile(param)
+		if err != nil {
+			return fmt.Errorf("tag setting %q: %w", name, err)
+		}
+		r.message = "must match " + param
+		r.check = func(v reflect.Value) bool { return re.MatchString(v.String()) }
+	case "oneof":
+		options := strings.Fields(param)
+		if len(options) == 0 {
+			return fmt.Errorf("tag setting %q requires a value", name)
+		}
+		r.message = "must be one of " + strings.Join(options, ", ")
+		r.check = func(v reflect.Value) bool {
+			s := fmt.Sprint(v.Interface())
+			for _, option := range options {
+				if s == option {
+					return true
+				}
+			}
+			return false
+		}
+	}
+	f.rules = append(f.rules, r)
+	return nil
+}
+
+// checkRules returns an error if a validation rule of field does not apply to its type t.
+func checkRules(field *Field, t reflect.Type) error {
+	v := reflect.New(t).Elem()
+	for _, r := range field.rules {
+		var ok bool
+		switch r.name {
+		case "min", "max":
+			_, ok = magnitude(v)
+		case "len":
+			_, ok = length(v)
+		case "regex":
+			ok = t.Kind() == reflect.String
+		default:
+			ok = true
+		}
+		if !ok {
+			return fmt.Errorf("tag setting %q does not apply to %s", r.name, t)
+		}
+	}
+	return nil
+}
+
+// length returns the length of strings, in runes, and of slices, arrays and maps.
+func length(v reflect.Value) (int, bool) {
+	switch v.Kind() {
+	case reflect.String:
+		return utf8.RuneCountInString(v.String()), true
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return v.Len(), true
+	}
+	return 0, false
+}
+
+// magnitude returns the value of numbers and the length of other values, as checked by min and max.
+func magnitude(v reflect.Value) (float64, bool) {
+	switch {
+	case v.CanInt():
+		return float64(v.Int()), true
+	case v.CanUint():
+		return float64(v.Uint()), true
+	case v.CanFloat():
+		return v.Float(), true
+	}
+	n, ok := length(v)
+	return float64(n), ok
+}
+
+// validate returns the errors of the rules of the field which v, a value of the field, fails.
+func (f *Field) validate(v reflect.Value) []FieldError {
+	// Unwrap pointers and interfaces; rules other than required do not apply to nil.
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			if f.required {
+				return []FieldError{{Field: f.Name, Rule: "required", Message: "is required"}}
+			}
+			return nil
+		}
+		v = v.Elem()
+	}
+	if f.required && v.IsZero() {
+		return []FieldError{{Field: f.Name, Rule: "required", Message: "is required"}}
+	}
+
+	var errs []FieldError
+	for _, r := range f.rules {
+		if !r.check(v) {
+			errs = append(errs, FieldError{Field: f.Name, Rule: r.name, Message: r.message})
+		}
+	}
+	return errs
+}
+
+// Validate checks the fields of value, a model or pointer to a model, against the rules of their tags,
+// then calls its Validate method if it has one. It returns ValidationErrors listing every failure, or nil.
+// An error returned by Validate which is not ValidationErrors is listed without a field name.
+func (s *Schema) Validate(value interface{}) error {
+	record := reflect.Indirect(reflect.ValueOf(value))
+	var errs ValidationErrors
+	for _, field := range s.Fields {
+		errs = append(errs, field.validate(field.ValueOf(record))...)
+	}
+
+	if validator, ok := value.(Validator); ok {
+		if err := validator.Validate(); err != nil {
+			var verrs ValidationErrors
+			if errors.As(err, &verrs) {
+				errs = append(errs, verrs...)
+			} else {
+				errs = append(errs, FieldError{Rule: "validate", Message: err.Error()})
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// ValidateColumns checks the values of an update, keyed by column name, against the rules of their fields.
+// Columns without a field and values which are not of the field's type are left unchecked.
+func (s *Schema) ValidateColumns(columns map[string]interface{}) error {
+	var errs ValidationErrors
+	for _, field := range s.Fields {
+		value, ok := columns[field.Column]
+		if !ok {
+			continue
+		}
+		v := reflect.ValueOf(value)
+		if value != nil && !v.Type().AssignableTo(field.goType) {
+			continue
+		}
+		if value == nil {
+			v = reflect.Zero(field.goType)
+		}
+		errs = append(errs, field.validate(v)...)
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
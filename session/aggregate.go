@@ -0,0 +1,145 @@
+package session
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"tsorm/clause"
+	"tsorm/expr"
+	"tsorm/schema"
+)
+
+// Group groups the records of the next query by the given columns or field names.
+func (s *Session) Group(columns ...string) *Session {
+	s.group = append(s.group, columns...)
+	return s
+}
+
+// Having adds a condition on the groups of the next query, ANDed with the conditions added before.
+// query is either a SQL condition with "?" placeholders for args, or a clause.Expression, which takes no args.
+func (s *Session) Having(query interface{}, args ...interface{}) *Session {
+	s.having = append(s.having, s.condition(query, args))
+	return s
+}
+
+// groupColumns returns the column names of the columns or field names of table added by Group.
+func (s *Session) groupColumns(table *schema.Schema) []string {
+	columns := make([]string, len(s.group))
+	for i, name := range s.group {
+		columns[i] = columnOf(table, name)
+	}
+	return columns
+}
+
+// setGroupHaving sets the GROUP BY clause from the columns added by Group
+// and the HAVING clause from the conditions added by Having.
+func (s *Session) setGroupHaving(table *schema.Schema) {
+	if len(s.group) > 0 {
+		var values []interface{}
+		for _, column := range s.groupColumns(table) {
+			values = append(values, column)
+		}
+		s.clause.Set(clause.GROUPBY, values...)
+	}
+	if len(s.having) > 0 {
+		sql, vars := expr.And(s.having...).Build(s.dialect)
+		s.clause.Set(clause.HAVING, append([]interface{}{sql}, vars...)...)
+	}
+}
+
+// Scan runs a query on the table of the model and appends its rows to dest, a pointer to a slice of structs.
+// The columns of the struct are selected, so a column tag can hold an aggregate expression such as
+// `tsorm:"column:SUM(Amount)"`. Where, Group, Having, OrderBy, Limit and Offset apply to the query.
+func (s *Session) Scan(dest interface{}) error {
+	defer s.Clear()
+	destSlice := reflect.Indirect(reflect.ValueOf(dest))
+	if destSlice.Kind() != reflect.Slice {
+		return fmt.Errorf("scan: destination must be a pointer to a slice, got %T", dest)
+	}
+	destType := destSlice.Type().Elem()
+	row, err := schema.ParseCachedE(reflect.New(destType).Interface(), s.dialect, schema.WithNamer(s.namer))
+	if err != nil {
+		return err
+	}
+	table, err := s.table()
+	if err != nil {
+		return err
+	}
+
+	s.clause.Set(clause.SELECT, table.Name, row.FieldNames)
+	s.setWhere(table)
+	s.setGroupHaving(table)
+	s.setLimitOffset()
+	sql, vars := s.clause.Build(clause.SELECT, clause.WHERE, clause.GROUPBY, clause.HAVING, clause.ORDERBY, clause.LIMIT, clause.OFFSET)
+	rows, err := s.Raw(sql, vars...).QueryRows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		dest := reflect.New(destType).Elem()
+		var values []interface{}
+		for _, field := range row.Fields {
+			values = append(values, field.ScanDest(dest))
+		}
+		if err := rows.Scan(values...); err != nil {
+			return err
+		}
+		destSlice.Set(reflect.Append(destSlice, dest))
+	}
+	return rows.Err()
+}
+
+// Sum stores the sum of column over the records matching the conditions in dest, a pointer such as *int64
+// or *float64. dest is set to its zero value if there are no records.
+func (s *Session) Sum(column string, dest interface{}) error {
+	return s.aggregate("SUM", column, dest)
+}
+
+// Avg stores the average of column over the records matching the conditions in dest, a pointer such as *float64.
+// dest is set to its zero value if there are no records.
+func (s *Session) Avg(column string, dest interface{}) error {
+	return s.aggregate("AVG", column, dest)
+}
+
+// Min stores the smallest value of column among the records matching the conditions in dest, a pointer to
+// a value of the column type. dest is set to its zero value if there are no records.
+func (s *Session) Min(column string, dest interface{}) error {
+	return s.aggregate("MIN", column, dest)
+}
+
+// Max stores the largest value of column among the records matching the conditions in dest, a pointer to
+// a value of the column type. dest is set to its zero value if there are no records.
+func (s *Session) Max(column string, dest interface{}) error {
+	return s.aggregate("MAX", column, dest)
+}
+
+// aggregate stores the aggregate function fn of column, a column or field name, over the records of the table
+// matching the conditions in dest.
+func (s *Session) aggregate(fn, column string, dest interface{}) error {
+	defer s.Clear()
+	v := reflect.ValueOf(dest)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return fmt.Errorf("%s: destination must be a non-nil pointer, got %T", strings.ToLower(fn), dest)
+	}
+	table, err := s.table()
+	if err != nil {
+		return err
+	}
+	s.clause.Set(clause.SELECT, table.Name, []string{fmt.Sprintf("%s(%s)", fn, clause.Quote(s.dialect, columnOf(table, column)))})
+	s.setWhere(table)
+	query, vars := s.clause.Build(clause.SELECT, clause.WHERE)
+
+	// Scan through a pointer to a pointer, which reads NULL, the aggregate of no records, as nil.
+	result := reflect.New(v.Type())
+	if err := s.Raw(query, vars...).QueryRow().Scan(result.Interface()); err != nil {
+		return err
+	}
+	if result.Elem().IsNil() {
+		v.Elem().Set(reflect.Zero(v.Elem().Type()))
+	} else {
+		v.Elem().Set(result.Elem().Elem())
+	}
+	return nil
+}
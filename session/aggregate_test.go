@@ -0,0 +1,93 @@
+package session
+
+import (
+	"reflect"
+	"testing"
+)
+
+// Sale is a model for aggregate queries.
+type Sale struct {
+	ID     int
+	Region string
+	Amount int
+}
+
+// RegionReport is a row of sales aggregated per region.
+type RegionReport struct {
+	Region string
+	Total  int     `tsorm:"column:SUM(Amount)"`
+	Count  int     `tsorm:"column:COUNT(*)"`
+	Mean   float64 `tsorm:"column:AVG(Amount)"`
+}
+
+// newSalesSession returns a session on a fresh Sale table.
+func newSalesSession(t *testing.T) *Session {
+	s := NewSessionForTest(t).Model(&Sale{})
+	_ = s.DropTable()
+	_ = s.CreateTable()
+	_, _ = s.Insert(
+		&Sale{1, "north", 10}, &Sale{2, "north", 30},
+		&Sale{3, "south", 5},
+		&Sale{4, "east", 20}, &Sale{5, "east", 40},
+	)
+	return s
+}
+
+// TestSession_Aggregates tests Sum, Avg, Min and Max with typed destinations.
+func TestSession_Aggregates(t *testing.T) {
+	s := newSalesSession(t)
+	var sum, min int64
+	var avg float64
+	var max string
+	if err := s.Sum("Amount", &sum); err != nil || sum != 105 {
+		t.Error("Sum: got", sum, err)
+	}
+	if err := s.Avg("Amount", &avg); err != nil || avg != 21 {
+		t.Error("Avg: got", avg, err)
+	}
+	if err := s.Min("Amount", &min); err != nil || min != 5 {
+		t.Error("Min: got", min, err)
+	}
+	if err := s.Max("Region", &max); err != nil || max != "south" {
+		t.Error("Max: got", max, err)
+	}
+
+	// Integer sums keep their precision.
+	_, _ = s.Insert(&Sale{6, "west", 1 << 53}, &Sale{7, "west", 1})
+	if err := s.Where("Region = ?", "west").Sum("Amount", &sum); err != nil || sum != 1<<53+1 {
+		t.Fatal("failed to sum large integers", sum, err)
+	}
+	if err := s.Where("Region = ?", "none").Max("Amount", &sum); err != nil || sum != 0 {
+		t.Fatal("expected 0 for no records", sum, err)
+	}
+	if err := s.Sum("Amount", sum); err == nil {
+		t.Fatal("expected an error for a non-pointer destination")
+	}
+}
+
+// TestSession_GroupHavingScan tests aggregated reports with Group, Having and Scan.
+func TestSession_GroupHavingScan(t *testing.T) {
+	s := newSalesSession(t)
+	var rows []RegionReport
+	err := s.Where("Amount > ?", 1).Group("Region").Having("SUM(Amount) > ?", 10).OrderBy("Region").Scan(&rows)
+	expected := []RegionReport{
+		{Region: "east", Total: 60, Count: 2, Mean: 30},
+		{Region: "north", Total: 40, Count: 2, Mean: 20},
+	}
+	if err != nil || !reflect.DeepEqual(rows, expected) {
+		t.Fatal("failed to scan report", rows, err)
+	}
+}
+
+// TestSession_GroupCount tests that Count and FindAndCount count groups after Group and Having.
+func TestSession_GroupCount(t *testing.T) {
+	s := newSalesSession(t)
+	if count, err := s.Group("Region").Count(); err != nil || count != 3 {
+		t.Fatal("failed to count groups", count, err)
+	}
+	var sales []Sale
+	count, err := s.Model(&Sale{}).Group("Region").Having("SUM(Amount) > ?", 10).FindAndCount(&sales)
+	if err != nil || count != 2 {
+		t.Fatal("failed to count groups with FindAndCount", count, err)
+	}
+}
@@ -0,0 +1,19 @@
+package session
+
+import "errors"
+
+// Errors returned by the session.
+var (
+	// ErrRecordNotFound is returned by First and Get when no record matches.
+	ErrRecordNotFound = errors.New("NOT FOUND")
+	// ErrMissingPrimaryKey is returned by primary-key based methods on models without a primary key.
+	ErrMissingPrimaryKey = errors.New("model has no primary key")
+	// ErrModelNotSet is returned by statements needing a model when none was set.
+	ErrModelNotSet = errors.New("model is not set")
+	// ErrNoColumns is returned when Select and Omit leave no column to read or write.
+	ErrNoColumns = errors.New("no columns selected")
+	// ErrRecordDeleted is returned by Save when the record is soft-deleted.
+	ErrRecordDeleted = errors.New("record is soft-deleted")
+	// ErrStaleObject is returned when updating a versioned model which was changed or deleted since it was read.
+	ErrStaleObject = errors.New("stale object: record was changed or deleted since it was read")
+)
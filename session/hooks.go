@@ -2,7 +2,6 @@ package session
 
 import (
 	"reflect"
-	"tsorm/log"
 )
 
 // Constants representing different lifecycle events.
@@ -19,13 +18,16 @@ const (
 
 // CallMethod calls the specified method on the value using reflection.
 // It accepts the method name and the value on which the method should be called.
+// Hooks receive the session, so they can read the statement context through s.Context().
 func (s *Session) CallMethod(method string, value interface{}) {
-	// Get the method of the model associated with the session.
-	fm := reflect.ValueOf(s.RefTable().Model).MethodByName(method)
-	// If a specific value is provided, get the method from that value instead.
-	if value != nil {
-		fm = reflect.ValueOf(value).MethodByName(method)
+	// Call the method on the model associated with the session unless a specific value is provided.
+	if value == nil {
+		value = s.model
 	}
+	if value == nil {
+		return
+	}
+	fm := reflect.ValueOf(value).MethodByName(method)
 
 	// Prepare the parameters for the method call.
 	param := []reflect.Value{reflect.ValueOf(s)}
@@ -36,7 +38,7 @@ func (s *Session) CallMethod(method string, value interface{}) {
 		if v := fm.Call(param); len(v) > 0 {
 			// If the method returns an error, log it.
 			if err, ok := v[0].Interface().(error); ok {
-				log.Error(err)
+				s.logger.Error(err)
 			}
 		}
 	}
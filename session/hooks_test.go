@@ -1,6 +1,7 @@
 package session
 
 import (
+	"context"
 	"testing"
 	"tsorm/log"
 )
@@ -49,3 +50,31 @@ func TestSession_CallMethod(t *testing.T) {
 		t.Fatal("test failed, got", u)
 	}
 }
+
+// ctxKey is the context key type used by the hook context test.
+type ctxKey struct{}
+
+// Tracked is a model whose hook records a value read from the session context.
+type Tracked struct {
+	Name    string
+	Tracker string
+}
+
+// BeforeInsert copies the value stored in the session context into the record.
+func (tracked *Tracked) BeforeInsert(s *Session) error {
+	tracked.Tracker, _ = s.Context().Value(ctxKey{}).(string)
+	return nil
+}
+
+// TestSession_HookContext tests that hooks can see the context of the session.
+func TestSession_HookContext(t *testing.T) {
+	ctx := context.WithValue(context.Background(), ctxKey{}, "request-1")
+	s := NewSessionForTest(t).WithContext(ctx).Model(&Tracked{})
+	_ = s.DropTable()
+	_ = s.CreateTable()
+
+	tracked := &Tracked{Name: "Tom"}
+	if _, err := s.Insert(tracked); err != nil || tracked.Tracker != "request-1" {
+		t.Fatal("hook failed to read the session context, got", tracked, err)
+	}
+}
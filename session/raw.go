@@ -1,8 +1,10 @@
 package session
 
 import (
+	"context"
 	"database/sql"
 	"strings"
+	"time"
 	"tsorm/clause"
 	"tsorm/dialect"
 	"tsorm/log"
@@ -11,13 +13,28 @@ import (
 
 // Session represents a database session.
 type Session struct {
-	db       *sql.DB         // db is the underlying SQL database connection.
-	dialect  dialect.Dialect // dialect is the SQL dialect used by the session.
-	tx       *sql.Tx         // tx is the SQL transaction associated with the session.
-	refTable *schema.Schema  // refTable is the schema of the model associated with the session.
-	clause   clause.Clause   // clause represents the SQL clauses used by the session.
-	sql      strings.Builder // sql is the SQL query being constructed.
-	sqlVars  []interface{}   // sqlVars contains the values to be used in the SQL query.
+	db       *sql.DB             // db is the underlying SQL database connection.
+	ctx      context.Context     // ctx is the context passed to every statement and transaction of the session.
+	dialect  dialect.Dialect     // dialect is the SQL dialect used by the session.
+	logger   log.Logger          // logger receives the statements and errors of the session.
+	namer    schema.Namer        // namer maps model names to table and column names.
+	nowFunc  func() time.Time    // nowFunc returns the current time for automatic timestamps.
+	tx       *sql.Tx             // tx is the SQL transaction associated with the session.
+	refTable *schema.Schema      // refTable is the schema of the model associated with the session.
+	modelErr error               // modelErr is the error of parsing the last model, returned by statements needing it.
+	condErr  error               // condErr is the error of the conditions of the next statement, returned by it.
+	model    interface{}         // model is the value last passed to Model, on which hooks are called.
+	clause   clause.Clause       // clause represents the SQL clauses used by the session.
+	where    []clause.Expression // where holds the conditions set by Where, Or and Not, ANDed into clause.WHERE.
+	group    []string            // group holds the columns set by Group, set into clause.GROUPBY.
+	having   []clause.Expression // having holds the conditions set by Having, ANDed into clause.HAVING.
+	selects  []string            // selects are the columns set by Select, all columns if empty.
+	omits    []string            // omits are the columns set by Omit.
+	unscoped bool                // unscoped reports whether soft-deleted records are included in the next statement.
+	limit    int                 // limit is the maximum number of records of the next query, -1 if not set.
+	offset   int                 // offset is the number of records the next query skips.
+	sql      strings.Builder     // sql is the SQL query being constructed.
+	sqlVars  []interface{}       // sqlVars contains the values to be used in the SQL query.
 }
 
 // CommonDB represents the common methods shared by both *sql.DB and *sql.Tx.
@@ -25,26 +42,86 @@ type CommonDB interface {
 	Query(query string, args ...interface{}) (*sql.Rows, error)
 	QueryRow(query string, args ...interface{}) *sql.Row
 	Exec(query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
 }
 
 // Check if *sql.DB and *sql.Tx implement the CommonDB interface.
 var _ CommonDB = (*sql.DB)(nil)
 var _ CommonDB = (*sql.Tx)(nil)
 
+// Option configures a Session created by NewSession.
+type Option func(*Session)
+
+// WithLogger sets the logger used by the session.
+func WithLogger(logger log.Logger) Option {
+	return func(s *Session) {
+		if logger != nil {
+			s.logger = logger
+		}
+	}
+}
+
+// WithNamer sets the naming strategy used when parsing models.
+func WithNamer(namer schema.Namer) Option {
+	return func(s *Session) {
+		s.namer = namer
+	}
+}
+
+// WithNowFunc sets the clock used to fill CreatedAt and UpdatedAt fields.
+func WithNowFunc(now func() time.Time) Option {
+	return func(s *Session) {
+		if now != nil {
+			s.nowFunc = now
+		}
+	}
+}
+
 // NewSession creates a new session with the given SQL database and dialect.
-func NewSession(db *sql.DB, dialect dialect.Dialect) *Session {
-	return &Session{
+func NewSession(db *sql.DB, dialect dialect.Dialect, opts ...Option) *Session {
+	s := &Session{
 		db:      db,
 		dialect: dialect,
+		logger:  log.Default(),
+		nowFunc: time.Now,
+		limit:   -1,
 		tx:      nil,
+		clause:  clause.New(dialect),
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
 // Clear resets the session's state by clearing the SQL query and variables, and resetting the clause.
+// Statements call it when they return, even on error, so their conditions never apply to the next one.
 func (s *Session) Clear() {
 	s.sql.Reset()
 	s.sqlVars = nil
-	s.clause = clause.Clause{}
+	s.clause = clause.New(s.dialect)
+	s.where, s.condErr = nil, nil
+	s.group, s.having = nil, nil
+	s.selects, s.omits = nil, nil
+	s.unscoped = false
+	s.limit, s.offset = -1, 0
+}
+
+// WithContext sets the context used by the statements and transactions of the session.
+// Hooks receive the session and can read the context through Context.
+func (s *Session) WithContext(ctx context.Context) *Session {
+	s.ctx = ctx
+	return s
+}
+
+// Context returns the context of the session, or context.Background() if none was set.
+func (s *Session) Context() context.Context {
+	if s.ctx == nil {
+		return context.Background()
+	}
+	return s.ctx
 }
 
 // DB returns the underlying SQL database connection or transaction.
@@ -57,20 +134,30 @@ func (s *Session) DB() CommonDB {
 }
 
 // Raw appends raw SQL query and values to the session's SQL query.
+// Placeholders are written "?" for every dialect and rebound when the statement runs, see clause.Rebind.
+// Slice values are expanded into one "?" placeholder per element, see clause.Expand.
 func (s *Session) Raw(sql string, values ...interface{}) *Session {
+	sql, values = clause.Expand(sql, values)
 	s.sql.WriteString(sql)
 	s.sql.WriteString(" ")
 	s.sqlVars = append(s.sqlVars, values...)
 	return s
 }
 
+// statement returns the SQL query built by the session, with its "?" placeholders rebound for the dialect.
+// Statements built from clauses are rebound already and left unchanged.
+func (s *Session) statement() string {
+	return clause.Rebind(s.dialect, s.sql.String())
+}
+
 // Exec executes the SQL query built by the session and returns the result.
 func (s *Session) Exec() (result sql.Result, err error) {
 	defer s.Clear()
-	log.Info(s.sql.String(), s.sqlVars)
+	sql := s.statement()
+	s.logger.Info(sql, s.sqlVars)
 
-	if result, err = s.DB().Exec(s.sql.String(), s.sqlVars...); err != nil {
-		log.Error(err)
+	if result, err = s.DB().ExecContext(s.Context(), sql, s.sqlVars...); err != nil {
+		s.logger.Error(err)
 	}
 	return
 }
@@ -78,16 +165,18 @@ func (s *Session) Exec() (result sql.Result, err error) {
 // QueryRow executes the SQL query built by the session and returns a single row result.
 func (s *Session) QueryRow() *sql.Row {
 	defer s.Clear()
-	log.Info(s.sql.String(), s.sqlVars)
-	return s.DB().QueryRow(s.sql.String(), s.sqlVars...)
+	sql := s.statement()
+	s.logger.Info(sql, s.sqlVars)
+	return s.DB().QueryRowContext(s.Context(), sql, s.sqlVars...)
 }
 
 // QueryRows executes the SQL query built by the session and returns multiple row results.
 func (s *Session) QueryRows() (rows *sql.Rows, err error) {
 	defer s.Clear()
-	log.Info(s.sql.String(), s.sqlVars)
-	if rows, err = s.DB().Query(s.sql.String(), s.sqlVars...); err != nil {
-		log.Error(err)
+	sql := s.statement()
+	s.logger.Info(sql, s.sqlVars)
+	if rows, err = s.DB().QueryContext(s.Context(), sql, s.sqlVars...); err != nil {
+		s.logger.Error(err)
 	}
 	return
 }
@@ -0,0 +1,27 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// TestSession_WithContext tests that statements honour the context of the session.
+func TestSession_WithContext(t *testing.T) {
+	// Cancel the context before running any statement.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	s := NewSessionForTest(t).WithContext(ctx)
+	if s.Context() != ctx {
+		t.Fatal("failed to set context")
+	}
+
+	// Both Exec and QueryRows must fail with the cancellation error.
+	if _, err := s.Raw("SELECT 1").Exec(); !errors.Is(err, context.Canceled) {
+		t.Fatal("expected context.Canceled from Exec, got", err)
+	}
+	if _, err := s.Raw("SELECT 1").QueryRows(); !errors.Is(err, context.Canceled) {
+		t.Fatal("expected context.Canceled from QueryRows, got", err)
+	}
+}
@@ -1,45 +1,245 @@
 package session
 
 import (
-	"errors"
+	"database/sql"
+	"fmt"
 	"reflect"
+	"strings"
+	"time"
 	"tsorm/clause"
+	"tsorm/expr"
+	"tsorm/schema"
 )
 
 // Insert inserts one or more records into the database.
 // It invokes BeforeInsert and AfterInsert callbacks if defined.
+// Zero auto-increment keys are left to the database and written back into the records passed as pointers.
+// As multi-row inserts do not tell which generated key belongs to which row, even with RETURNING, whose
+// order is unspecified, a batch with zero keys is intentionally inserted one row at a time, within a
+// transaction unless the session is in one already.
+// Nothing is inserted if a record fails validation, see schema.Schema.Validate.
 func (s *Session) Insert(values ...interface{}) (int64, error) {
-	recordValues := make([]interface{}, 0)
+	defer s.Clear()
+	if len(values) == 0 {
+		return 0, nil
+	}
+	var table *schema.Schema
+	now := s.nowFunc()
 	for _, value := range values {
 		s.CallMethod(BeforeInsert, value)
+		if _, err := s.ModelE(value); err != nil {
+			return 0, err
+		}
+		table = s.refTable
+		// Fill the zero automatic timestamps of records passed as pointers.
+		if record := reflect.ValueOf(value); record.Kind() == reflect.Ptr {
+			setTimestamps(table, record.Elem(), now, true)
+			setInitialVersion(table, record.Elem())
+		}
+	}
+	// Abort before writing anything if a record is invalid.
+	for _, value := range values {
+		if err := table.Validate(value); err != nil {
+			return 0, err
+		}
+	}
+
+	// Select and Omit are reset by every statement, so resolve the columns once.
+	var columns []string
+	for _, field := range s.selectedFields(table) {
+		columns = append(columns, field.Column)
+	}
 
-		table := s.Model(value).RefTable()
-		s.clause.Set(clause.INSERT, table.Name, table.FieldNames)
-		recordValues = append(recordValues, table.RecordValues(value))
+	insert := s.insertRows
+	if len(values) > 1 && hasZeroAutoIncrement(table, values) {
+		insert = s.insertEach
+	}
+	affected, err := insert(table, columns, values)
+	if err != nil {
+		return 0, err
 	}
 
+	s.CallMethod(AfterInsert, nil)
+	return affected, nil
+}
+
+// hasZeroAutoIncrement reports whether the auto-increment key of any of values is zero.
+func hasZeroAutoIncrement(table *schema.Schema, values []interface{}) bool {
+	if table.AutoIncrement == nil {
+		return false
+	}
+	for _, value := range values {
+		if table.AutoIncrement.ValueOf(reflect.Indirect(reflect.ValueOf(value))).IsZero() {
+			return true
+		}
+	}
+	return false
+}
+
+// insertEach inserts values one row at a time, so that every generated key is written back into its record.
+// The rows are inserted within a transaction unless the session is in one already.
+func (s *Session) insertEach(table *schema.Schema, columns []string, values []interface{}) (int64, error) {
+	owned := s.tx == nil
+	if owned {
+		if err := s.Begin(); err != nil {
+			return 0, err
+		}
+		defer func() { s.tx = nil }()
+	}
+
+	var affected int64
+	for _, value := range values {
+		n, err := s.insertRows(table, columns, []interface{}{value})
+		if err != nil {
+			if owned {
+				_ = s.Rollback()
+			}
+			return 0, err
+		}
+		affected += n
+	}
+	if owned {
+		if err := s.Commit(); err != nil {
+			return 0, err
+		}
+	}
+	return affected, nil
+}
+
+// insertRows inserts values in a single statement, writing only the given columns.
+// The auto-increment column of a single row with a zero key is left out and its generated key written back.
+func (s *Session) insertRows(table *schema.Schema, columns []string, values []interface{}) (int64, error) {
+	autoInc := table.AutoIncrement
+	if autoInc != nil && (len(values) > 1 || !hasZeroAutoIncrement(table, values)) {
+		autoInc = nil
+	}
+	if autoInc != nil {
+		columns = without(columns, autoInc.Column)
+	}
+	if len(columns) == 0 {
+		return 0, ErrNoColumns
+	}
+
+	recordValues := make([]interface{}, 0, len(values))
+	for _, value := range values {
+		recordValues = append(recordValues, table.RecordValues(value, columns...))
+	}
+	s.clause.Set(clause.INSERT, table.Name, columns)
 	s.clause.Set(clause.VALUES, recordValues...)
+
+	// Read the generated key from RETURNING when the dialect prefers it.
+	if autoInc != nil && s.dialect.UseReturning() {
+		s.clause.Set(clause.RETURNING, []string{autoInc.Column})
+		sql, vars := s.clause.Build(clause.INSERT, clause.VALUES, clause.RETURNING)
+		var id int64
+		if err := s.Raw(sql, vars...).QueryRow().Scan(&id); err != nil {
+			return 0, err
+		}
+		setAutoIncrement(autoInc, values[0], id)
+		return 1, nil
+	}
+
 	sql, vars := s.clause.Build(clause.INSERT, clause.VALUES)
 	result, err := s.Raw(sql, vars...).Exec()
 	if err != nil {
 		return 0, err
 	}
-
-	s.CallMethod(AfterInsert, nil)
+	// Otherwise read it from LastInsertId.
+	if autoInc != nil {
+		id, err := result.LastInsertId()
+		if err != nil {
+			return 0, err
+		}
+		setAutoIncrement(autoInc, values[0], id)
+	}
 	return result.RowsAffected()
 }
 
+// setAutoIncrement writes id into the auto-increment field of value if value is a pointer to a model.
+func setAutoIncrement(autoInc *schema.Field, value interface{}, id int64) {
+	record := reflect.ValueOf(value)
+	if record.Kind() != reflect.Ptr {
+		return
+	}
+	field := reflect.ValueOf(autoInc.AddrOf(record.Elem())).Elem()
+	switch field.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		field.SetInt(id)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		field.SetUint(uint64(id))
+	}
+}
+
+// setTimestamps fills the automatic timestamp fields of record, an addressable struct value, with now.
+// On insert, only zero fields are filled; otherwise every AutoUpdateTime field is.
+func setTimestamps(table *schema.Schema, record reflect.Value, now time.Time, insert bool) {
+	for _, field := range table.Fields {
+		if field.AutoUpdateTime == 0 && (!insert || field.AutoCreateTime == 0) {
+			continue
+		}
+		if insert && !field.ValueOf(record).IsZero() {
+			continue
+		}
+		reflect.ValueOf(field.AddrOf(record)).Elem().Set(field.TimeOf(now))
+	}
+}
+
+// setInitialVersion sets the version field of record, an addressable struct value, to 1 if it is zero.
+func setInitialVersion(table *schema.Schema, record reflect.Value) {
+	if table.Version == nil || !table.Version.ValueOf(record).IsZero() {
+		return
+	}
+	field := reflect.ValueOf(table.Version.AddrOf(record)).Elem()
+	if field.CanInt() {
+		field.SetInt(1)
+	} else {
+		field.SetUint(1)
+	}
+}
+
+// without returns a copy of columns without the given column.
+func without(columns []string, column string) []string {
+	var rest []string
+	for _, c := range columns {
+		if c != column {
+			rest = append(rest, c)
+		}
+	}
+	return rest
+}
+
 // Find retrieves records from the database and populates the given slice.
 // It invokes BeforeQuery and AfterQuery callbacks if defined.
 func (s *Session) Find(values interface{}) error {
+	defer s.Clear()
 	s.CallMethod(BeforeQuery, nil)
 
 	destSlice := reflect.Indirect(reflect.ValueOf(values))
+	if destSlice.Kind() != reflect.Slice {
+		return fmt.Errorf("find: destination must be a pointer to a slice, got %T", values)
+	}
 	destType := destSlice.Type().Elem()
-	table := s.Model(reflect.New(destType).Elem().Interface()).RefTable()
+	if _, err := s.ModelE(reflect.New(destType).Elem().Interface()); err != nil {
+		return err
+	}
+	table, err := s.table()
+	if err != nil {
+		return err
+	}
+	fields := s.selectedFields(table)
+	if len(fields) == 0 {
+		return ErrNoColumns
+	}
+	columns := make([]string, len(fields))
+	for i, field := range fields {
+		columns[i] = field.Column
+	}
 
-	s.clause.Set(clause.SELECT, table.Name, table.FieldNames)
-	sql, vars := s.clause.Build(clause.SELECT, clause.WHERE, clause.ORDERBY, clause.LIMIT)
+	s.clause.Set(clause.SELECT, table.Name, columns)
+	s.setWhere(table)
+	s.setGroupHaving(table)
+	s.setLimitOffset()
+	sql, vars := s.clause.Build(clause.SELECT, clause.WHERE, clause.GROUPBY, clause.HAVING, clause.ORDERBY, clause.LIMIT, clause.OFFSET)
 	rows, err := s.Raw(sql, vars...).QueryRows()
 	if err != nil {
 		return err
@@ -48,8 +248,8 @@ func (s *Session) Find(values interface{}) error {
 	for rows.Next() {
 		dest := reflect.New(destType).Elem()
 		var values []interface{}
-		for _, name := range table.FieldNames {
-			values = append(values, dest.FieldByName(name).Addr().Interface())
+		for _, field := range fields {
+			values = append(values, field.ScanDest(dest))
 		}
 		if err := rows.Scan(values...); err != nil {
 			return err
@@ -61,8 +261,16 @@ func (s *Session) Find(values interface{}) error {
 }
 
 // Update updates records in the database with the specified key-value pairs.
+// Given a single pointer to a model instead, it updates every column of the record identified by its primary key;
+// see UpdateModel. Nothing is updated if a value fails validation.
 // It invokes BeforeUpdate and AfterUpdate callbacks if defined.
 func (s *Session) Update(kv ...interface{}) (int64, error) {
+	defer s.Clear()
+	if len(kv) == 1 {
+		if v := reflect.ValueOf(kv[0]); v.Kind() == reflect.Ptr && v.Elem().Kind() == reflect.Struct {
+			return s.UpdateModel(kv[0])
+		}
+	}
 	s.CallMethod(BeforeUpdate, nil)
 
 	m, ok := kv[0].(map[string]interface{})
@@ -72,10 +280,43 @@ func (s *Session) Update(kv ...interface{}) (int64, error) {
 			m[kv[i].(string)] = kv[i+1]
 		}
 	}
+	// Keys may be Go field names, which are mapped to their column names.
+	table, err := s.table()
+	if err != nil {
+		return 0, err
+	}
+	columns := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		if field := table.LookUpField(k); field != nil {
+			k = field.Column
+		}
+		columns[k] = v
+	}
+	// Set the AutoUpdateTime columns which are not updated explicitly.
+	for _, field := range table.Fields {
+		if _, ok := columns[field.Column]; field.AutoUpdateTime != 0 && !ok {
+			columns[field.Column] = field.TimeOf(s.nowFunc()).Interface()
+		}
+	}
+	if err := table.ValidateColumns(columns); err != nil {
+		return 0, err
+	}
+	// Encode the values of serialized fields, leaving expressions as they are.
+	for column, v := range columns {
+		if _, ok := v.(clause.Expression); !ok {
+			if field := table.GetField(column); field != nil {
+				columns[column] = field.Encode(v)
+			}
+		}
+	}
+	// Bump the version so that models read before this update become stale.
+	if table.Version != nil {
+		if _, ok := columns[table.Version.Column]; !ok {
+			columns[table.Version.Column] = clause.Expr{SQL: s.dialect.Quote(table.Version.Column) + " + 1"}
+		}
+	}
 
-	s.clause.Set(clause.UPDATE, s.RefTable().Name, m)
-	sql, vars := s.clause.Build(clause.UPDATE, clause.WHERE)
-	result, err := s.Raw(sql, vars...).Exec()
+	result, err := s.update(table, columns)
 	if err != nil {
 		return 0, err
 	}
@@ -84,12 +325,126 @@ func (s *Session) Update(kv ...interface{}) (int64, error) {
 	return result.RowsAffected()
 }
 
+// UpdateModel updates every column of the record identified by the primary key of value, a pointer to a model.
+// For models with a version field, the record is only updated if its version still matches the one in value,
+// and the version is incremented; ErrStaleObject is returned if no record was updated.
+// Select and Omit restrict the columns which are updated, except for the version.
+// It invokes BeforeUpdate and AfterUpdate callbacks if defined.
+func (s *Session) UpdateModel(value interface{}) (int64, error) {
+	defer s.Clear()
+	s.CallMethod(BeforeUpdate, value)
+
+	if _, err := s.ModelE(value); err != nil {
+		return 0, err
+	}
+	table, err := s.table()
+	if err != nil {
+		return 0, err
+	}
+	affected, err := s.updateModel(table, value)
+	if err != nil {
+		return 0, err
+	}
+	if affected == 0 && table.Version != nil {
+		return 0, ErrStaleObject
+	}
+
+	s.CallMethod(AfterUpdate, value)
+	return affected, nil
+}
+
+// updateModel updates the columns of value which are not part of the primary key, leaving zero creation times untouched.
+// The version of value is checked and incremented if the table has one.
+func (s *Session) updateModel(table *schema.Schema, value interface{}) (int64, error) {
+	if err := table.Validate(value); err != nil {
+		return 0, err
+	}
+	pk, _ := primaryKeyValues(table, value)
+	desc, vars, err := s.primaryKeyWhere(table, pk)
+	if err != nil {
+		return 0, err
+	}
+
+	record := reflect.Indirect(reflect.ValueOf(value))
+	if record.CanAddr() {
+		setTimestamps(table, record, s.nowFunc(), false)
+	}
+	columns := make(map[string]interface{})
+	for _, field := range s.selectedFields(table) {
+		if field.AutoCreateTime != 0 && field.ValueOf(record).IsZero() {
+			continue
+		}
+		if !field.PrimaryKey {
+			columns[field.Column] = field.ColumnValue(record)
+		}
+	}
+
+	// Match the version which was read and store the next one.
+	var next reflect.Value
+	if version := table.Version; version != nil {
+		current := version.ValueOf(record)
+		next = reflect.New(current.Type()).Elem()
+		if current.CanInt() {
+			next.SetInt(current.Int() + 1)
+		} else {
+			next.SetUint(current.Uint() + 1)
+		}
+		desc += " AND " + s.dialect.Quote(version.Column) + " = ?"
+		vars = append(vars, current.Interface())
+		columns[version.Column] = next.Interface()
+	}
+	if len(columns) == 0 {
+		return 0, nil
+	}
+
+	result, err := s.Where(desc, vars...).update(table, columns)
+	if err != nil {
+		return 0, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	// Write the new version back into the model.
+	if next.IsValid() && affected > 0 && record.CanAddr() {
+		reflect.ValueOf(table.Version.AddrOf(record)).Elem().Set(next)
+	}
+	return affected, nil
+}
+
+// update sets the given columns of the records of table matching the WHERE clause.
+func (s *Session) update(table *schema.Schema, columns map[string]interface{}) (sql.Result, error) {
+	s.clause.Set(clause.UPDATE, table.Name, columns)
+	s.setWhere(table)
+	sql, vars := s.clause.Build(clause.UPDATE, clause.WHERE)
+	return s.Raw(sql, vars...).Exec()
+}
+
 // Delete deletes records from the database.
+// Records of models with a DeletedAt field are soft-deleted by setting it, unless the session is Unscoped.
 // It invokes BeforeDelete and AfterDelete callbacks if defined.
 func (s *Session) Delete() (int64, error) {
+	defer s.Clear()
 	s.CallMethod(BeforeDelete, nil)
 
-	s.clause.Set(clause.DELETE, s.RefTable().Name)
+	table, err := s.table()
+	if err != nil {
+		return 0, err
+	}
+	if table.DeletedAt != nil && !s.unscoped {
+		s.clause.Set(clause.UPDATE, table.Name, map[string]interface{}{table.DeletedAt.Column: s.nowFunc()})
+		s.setWhere(table)
+		sql, vars := s.clause.Build(clause.UPDATE, clause.WHERE)
+		result, err := s.Raw(sql, vars...).Exec()
+		if err != nil {
+			return 0, err
+		}
+		s.CallMethod(AfterDelete, nil)
+		return result.RowsAffected()
+	}
+
+	s.clause.Set(clause.DELETE, table.Name)
+	s.setWhere(table)
 	sql, vars := s.clause.Build(clause.DELETE, clause.WHERE)
 	result, err := s.Raw(sql, vars...).Exec()
 	if err != nil {
@@ -100,10 +455,37 @@ func (s *Session) Delete() (int64, error) {
 	return result.RowsAffected()
 }
 
-// Count counts the number of records in the database.
+// HardDelete physically deletes records from the database, including soft-deleted ones.
+func (s *Session) HardDelete() (int64, error) {
+	return s.Unscoped().Delete()
+}
+
+// Unscoped makes the next statement include soft-deleted records and delete records physically.
+func (s *Session) Unscoped() *Session {
+	s.unscoped = true
+	return s
+}
+
+// Count counts the number of records in the database, or the number of groups after Group.
 func (s *Session) Count() (int64, error) {
-	s.clause.Set(clause.COUNT, s.RefTable().Name)
-	sql, vars := s.clause.Build(clause.COUNT, clause.WHERE)
+	defer s.Clear()
+	table, err := s.table()
+	if err != nil {
+		return 0, err
+	}
+	s.setWhere(table)
+	var sql string
+	var vars []interface{}
+	if len(s.group) > 0 {
+		// Grouped records are counted as groups, through a subquery selecting one row per group.
+		s.clause.Set(clause.SELECT, table.Name, s.groupColumns(table))
+		s.setGroupHaving(table)
+		sql, vars = s.clause.Build(clause.SELECT, clause.WHERE, clause.GROUPBY, clause.HAVING)
+		sql = fmt.Sprintf("SELECT COUNT(*) FROM (%s) AS %s", sql, clause.Quote(s.dialect, "grouped"))
+	} else {
+		s.clause.Set(clause.COUNT, table.Name)
+		sql, vars = s.clause.Build(clause.COUNT, clause.WHERE)
+	}
 	row := s.Raw(sql, vars...).QueryRow()
 	var temp int64
 	if err := row.Scan(&temp); err != nil {
@@ -112,19 +494,142 @@ func (s *Session) Count() (int64, error) {
 	return temp, nil
 }
 
+// Select restricts the columns read by Find and First and written by Insert and struct-based updates
+// to the given columns or field names. Fields which are not read are left untouched in the destination.
+func (s *Session) Select(columns ...string) *Session {
+	s.selects = append(s.selects, columns...)
+	return s
+}
+
+// Omit excludes the given columns or field names from the columns read by Find and First and
+// written by Insert and struct-based updates.
+func (s *Session) Omit(columns ...string) *Session {
+	s.omits = append(s.omits, columns...)
+	return s
+}
+
+// selectedFields returns the fields of table restricted by Select and Omit, in declaration order.
+func (s *Session) selectedFields(table *schema.Schema) []*schema.Field {
+	if len(s.selects) == 0 && len(s.omits) == 0 {
+		return table.Fields
+	}
+	selected, omitted := columnSet(table, s.selects), columnSet(table, s.omits)
+	var fields []*schema.Field
+	for _, field := range table.Fields {
+		if (len(selected) == 0 || selected[field.Column]) && !omitted[field.Column] {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// columnSet returns the set of column names of the given columns or field names of table.
+func columnSet(table *schema.Schema, names []string) map[string]bool {
+	set := make(map[string]bool, len(names))
+	for _, name := range names {
+		set[columnOf(table, name)] = true
+	}
+	return set
+}
+
+// columnOf returns the column name of the given column or field name of table.
+func columnOf(table *schema.Schema, name string) string {
+	if field := table.LookUpField(name); field != nil {
+		return field.Column
+	}
+	return name
+}
+
 // Limit specifies the maximum number of records to retrieve from the database.
 func (s *Session) Limit(num int) *Session {
-	s.clause.Set(clause.LIMIT, num)
+	s.limit = num
 	return s
 }
 
-// Where specifies the condition for selecting records from the database.
-func (s *Session) Where(desc string, args ...interface{}) *Session {
-	var vars []interface{}
-	s.clause.Set(clause.WHERE, append(append(vars, desc), args...)...)
+// Offset specifies the number of records to skip before retrieving records from the database.
+func (s *Session) Offset(num int) *Session {
+	s.offset = num
+	return s
+}
+
+// Paginate retrieves the given page of size records, pages being numbered from 1.
+func (s *Session) Paginate(page, size int) *Session {
+	if page < 1 {
+		page = 1
+	}
+	return s.Limit(size).Offset((page - 1) * size)
+}
+
+// setLimitOffset sets the LIMIT clause, or the OFFSET clause which the dialect renders with the limit.
+func (s *Session) setLimitOffset() {
+	if s.offset > 0 {
+		s.clause.Set(clause.OFFSET, s.offset, s.limit)
+	} else if s.limit >= 0 {
+		s.clause.Set(clause.LIMIT, s.limit)
+	}
+}
+
+// FindAndCount retrieves records like Find and returns the total number of records matching
+// the conditions, ignoring Limit, Offset and OrderBy, for paginated listings.
+func (s *Session) FindAndCount(values interface{}) (int64, error) {
+	defer s.Clear()
+	// Find resets the conditions, so keep them for Count.
+	where, group, having, unscoped := s.where, s.group, s.having, s.unscoped
+	if err := s.Find(values); err != nil {
+		return 0, err
+	}
+	s.where, s.group, s.having, s.unscoped = where, group, having, unscoped
+	return s.Count()
+}
+
+// Where adds a condition for the records of the next statement, ANDed with the conditions added before.
+// query is either a SQL condition with "?" placeholders for args, or a clause.Expression such as expr.Eq,
+// which carries its own variables and takes no args.
+func (s *Session) Where(query interface{}, args ...interface{}) *Session {
+	s.where = append(s.where, s.condition(query, args))
+	return s
+}
+
+// Or makes the records of the next statement match either the conditions added so far or the given one.
+func (s *Session) Or(query interface{}, args ...interface{}) *Session {
+	if len(s.where) == 0 {
+		return s.Where(query, args...)
+	}
+	s.where = []clause.Expression{expr.Or(expr.And(s.where...), s.condition(query, args))}
+	return s
+}
+
+// Not adds the negation of a condition, ANDed with the conditions added before.
+func (s *Session) Not(query interface{}, args ...interface{}) *Session {
+	s.where = append(s.where, expr.Not(s.condition(query, args)))
 	return s
 }
 
+// condition returns query as an expression, wrapping SQL strings with their args.
+// Args given with an expression, which carries its own variables, are an error returned by the next statement.
+func (s *Session) condition(query interface{}, args []interface{}) clause.Expression {
+	if e, ok := query.(clause.Expression); ok {
+		if len(args) > 0 && s.condErr == nil {
+			s.condErr = fmt.Errorf("condition: args %v given with expression %T, which takes no args", args, query)
+		}
+		return e
+	}
+	return clause.Expr{SQL: fmt.Sprint(query), Vars: args}
+}
+
+// setWhere sets the WHERE clause from the conditions added by Where, Or and Not,
+// excluding the soft-deleted records of table unless the session is Unscoped.
+func (s *Session) setWhere(table *schema.Schema) {
+	conds := s.where
+	if table.DeletedAt != nil && !s.unscoped {
+		conds = append(conds[:len(conds):len(conds)], expr.IsNull(table.DeletedAt.Column))
+	}
+	if len(conds) > 0 {
+		sql, vars := expr.And(conds...).Build(s.dialect)
+		s.clause.Set(clause.WHERE, append([]interface{}{sql}, vars...)...)
+	}
+}
+
 // OrderBy specifies the ordering of records retrieved from the database.
 func (s *Session) OrderBy(desc string) *Session {
 	s.clause.Set(clause.ORDERBY, desc)
@@ -134,14 +639,146 @@ func (s *Session) OrderBy(desc string) *Session {
 // First retrieves the first record from the database and populates the given value.
 // It returns an error if no record is found.
 func (s *Session) First(value interface{}) error {
+	defer s.Clear()
 	dest := reflect.Indirect(reflect.ValueOf(value))
+	// Find resets Select and Omit, so note the columns it reads.
+	var fields []*schema.Field
+	if len(s.selects) > 0 || len(s.omits) > 0 {
+		if _, err := s.ModelE(value); err != nil {
+			return err
+		}
+		fields = s.selectedFields(s.refTable)
+	}
+
 	destSlice := reflect.New(reflect.SliceOf(dest.Type())).Elem()
 	if err := s.Limit(1).Find(destSlice.Addr().Interface()); err != nil {
 		return err
 	}
 	if destSlice.Len() == 0 {
-		return errors.New("NOT FOUND")
+		return ErrRecordNotFound
+	}
+	if fields == nil {
+		dest.Set(destSlice.Index(0))
+		return nil
+	}
+	// Copy only the columns which were read, leaving the other fields untouched.
+	for _, field := range fields {
+		reflect.ValueOf(field.AddrOf(dest)).Elem().Set(field.ValueOf(destSlice.Index(0)))
 	}
-	dest.Set(destSlice.Index(0))
 	return nil
 }
+
+// primaryKeyWhere returns the WHERE condition matching the given primary key values of the table.
+func (s *Session) primaryKeyWhere(table *schema.Schema, pk []interface{}) (string, []interface{}, error) {
+	if len(table.PrimaryFields) == 0 {
+		return "", nil, ErrMissingPrimaryKey
+	}
+	if len(pk) != len(table.PrimaryFields) {
+		return "", nil, fmt.Errorf("table %s has %d primary key columns, got %d values", table.Name, len(table.PrimaryFields), len(pk))
+	}
+	conds := make([]string, len(table.PrimaryFields))
+	for i, field := range table.PrimaryFields {
+		conds[i] = s.dialect.Quote(field.Column) + " = ?"
+	}
+	return strings.Join(conds, " AND "), pk, nil
+}
+
+// primaryKeyValues returns the primary key values of value, and whether they are all zero.
+func primaryKeyValues(table *schema.Schema, value interface{}) (pk []interface{}, zero bool) {
+	record := reflect.Indirect(reflect.ValueOf(value))
+	zero = true
+	for _, field := range table.PrimaryFields {
+		v := field.ValueOf(record)
+		zero = zero && v.IsZero()
+		pk = append(pk, v.Interface())
+	}
+	return pk, zero
+}
+
+// Get retrieves the record with the given primary key into dest, a pointer to a model.
+// Composite keys take one value per primary key field, in declaration order.
+func (s *Session) Get(dest interface{}, pk ...interface{}) error {
+	defer s.Clear()
+	if _, err := s.ModelE(dest); err != nil {
+		return err
+	}
+	desc, vars, err := s.primaryKeyWhere(s.refTable, pk)
+	if err != nil {
+		return err
+	}
+	return s.Where(desc, vars...).First(dest)
+}
+
+// Save updates every column of the record identified by the primary key of value, a pointer to a model.
+// The record is inserted if its primary key is zero or if no record has that key yet.
+// For models with a version field, ErrStaleObject is returned if the record exists with another version.
+// ErrRecordDeleted is returned if the record is soft-deleted; saving it on an Unscoped session restores it.
+func (s *Session) Save(value interface{}) (int64, error) {
+	defer s.Clear()
+	if _, err := s.ModelE(value); err != nil {
+		return 0, err
+	}
+	table, err := s.table()
+	if err != nil {
+		return 0, err
+	}
+	pk, zero := primaryKeyValues(table, value)
+	if len(table.PrimaryFields) == 0 {
+		return 0, ErrMissingPrimaryKey
+	}
+	if zero {
+		return s.Insert(value)
+	}
+
+	unscoped := s.unscoped
+	s.CallMethod(BeforeUpdate, value)
+	affected, err := s.updateModel(table, value)
+	if err != nil {
+		return 0, err
+	}
+	if affected > 0 {
+		s.CallMethod(AfterUpdate, value)
+		return affected, nil
+	}
+
+	// Nothing was updated: insert the record unless it exists already, even soft-deleted.
+	desc, vars, err := s.primaryKeyWhere(table, pk)
+	if err != nil {
+		return 0, err
+	}
+	count, err := s.Unscoped().Where(desc, vars...).Count()
+	if err != nil {
+		return 0, err
+	}
+	if count > 0 {
+		if table.DeletedAt != nil && !unscoped {
+			live, err := s.Where(desc, vars...).Count()
+			if err != nil {
+				return 0, err
+			}
+			if live == 0 {
+				return 0, ErrRecordDeleted
+			}
+		}
+		if table.Version != nil {
+			return 0, ErrStaleObject
+		}
+		return 0, nil
+	}
+	return s.Insert(value)
+}
+
+// DeleteModel deletes the record identified by the primary key of value, a pointer to a model.
+func (s *Session) DeleteModel(value interface{}) (int64, error) {
+	defer s.Clear()
+	if _, err := s.ModelE(value); err != nil {
+		return 0, err
+	}
+	table := s.refTable
+	pk, _ := primaryKeyValues(table, value)
+	desc, vars, err := s.primaryKeyWhere(table, pk)
+	if err != nil {
+		return 0, err
+	}
+	return s.Where(desc, vars...).Delete()
+}
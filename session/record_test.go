@@ -1,6 +1,18 @@
 package session
 
-import "testing"
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+	"tsorm/dialect"
+	"tsorm/expr"
+	"tsorm/log"
+	"tsorm/schema"
+)
 
 var (
 	user1 = &User{"Tom", 18}
@@ -71,3 +83,695 @@ func TestSession_DeleteAndCount(t *testing.T) {
 		t.Fatal("failed to delete or count")
 	}
 }
+
+// Order is a model whose table and column names are SQL keywords.
+type Order struct {
+	Group string
+	Limit int
+}
+
+// TestSession_ReservedNames tests that table and column names which are SQL keywords are quoted.
+func TestSession_ReservedNames(t *testing.T) {
+	s := NewSessionForTest(t).Model(&Order{})
+	if err := s.DropTable(); err != nil {
+		t.Fatal("failed to drop table", err)
+	}
+	if err := s.CreateTable(); err != nil {
+		t.Fatal("failed to create table", err)
+	}
+	if _, err := s.Insert(&Order{Group: "a", Limit: 1}); err != nil {
+		t.Fatal("failed to insert", err)
+	}
+	if _, err := s.Update("Limit", 2); err != nil {
+		t.Fatal("failed to update", err)
+	}
+
+	var orders []Order
+	if err := s.Find(&orders); err != nil || len(orders) != 1 || orders[0].Limit != 2 {
+		t.Fatal("failed to query, got", orders, err)
+	}
+	if _, err := s.Delete(); err != nil {
+		t.Fatal("failed to delete", err)
+	}
+}
+
+// Profile is a model with nullable columns.
+type Profile struct {
+	Name     string
+	Age      *int
+	Nickname *string
+	Birthday *time.Time
+	Email    sql.NullString
+}
+
+// TestSession_Nullable tests writing and reading NULL through pointer and sql.Null* fields.
+func TestSession_Nullable(t *testing.T) {
+	s := NewSessionForTest(t).Model(&Profile{})
+	if sql := s.createTableSQL(); !strings.Contains(sql, `"Name" text NOT NULL`) || !strings.Contains(sql, `"Age" integer,`) {
+		t.Fatal("unexpected nullability in", sql)
+	}
+	_ = s.DropTable()
+	_ = s.CreateTable()
+
+	age, nickname, birthday := 30, "T", time.Date(2000, 1, 2, 0, 0, 0, 0, time.UTC)
+	_, err := s.Insert(
+		&Profile{Name: "Tom", Age: &age, Nickname: &nickname, Birthday: &birthday, Email: sql.NullString{String: "t@x", Valid: true}},
+		&Profile{Name: "Sam"},
+	)
+	if err != nil {
+		t.Fatal("failed to insert", err)
+	}
+
+	var profiles []Profile
+	if err := s.OrderBy("Name DESC").Find(&profiles); err != nil || len(profiles) != 2 {
+		t.Fatal("failed to query", profiles, err)
+	}
+	tom, sam := profiles[0], profiles[1]
+	if *tom.Age != 30 || *tom.Nickname != "T" || !tom.Birthday.Equal(birthday) || tom.Email.String != "t@x" {
+		t.Fatal("unexpected values", tom)
+	}
+	if sam.Age != nil || sam.Nickname != nil || sam.Birthday != nil || sam.Email.Valid {
+		t.Fatal("expected NULL values", sam)
+	}
+}
+
+// Entity holds the columns shared by every entity.
+type Entity struct {
+	ID      int `tsorm:"primaryKey"`
+	Version int
+}
+
+// Note is a model embedding Entity through a pointer.
+type Note struct {
+	*Entity
+	Text string
+}
+
+// TestSession_Embedded tests inserting and scanning models with embedded structs.
+func TestSession_Embedded(t *testing.T) {
+	s := NewSessionForTest(t).Model(&Note{})
+	_ = s.DropTable()
+	if err := s.CreateTable(); err != nil {
+		t.Fatal("failed to create table", err)
+	}
+	if _, err := s.Insert(&Note{Entity: &Entity{ID: 1, Version: 2}, Text: "hello"}); err != nil {
+		t.Fatal("failed to insert", err)
+	}
+
+	note := &Note{}
+	if err := s.First(note); err != nil || note.Entity == nil || note.ID != 1 || note.Version != 2 || note.Text != "hello" {
+		t.Fatal("failed to query", note, err)
+	}
+}
+
+// TestSession_ModelCached tests that switching models reuses the cached schemas.
+func TestSession_ModelCached(t *testing.T) {
+	s := NewSessionForTest(t)
+	users := s.Model(&User{}).RefTable()
+	accounts := s.Model(&Account{}).RefTable()
+	if users == accounts || s.Model(&User{}).RefTable() != users || NewSessionForTest(t).Model(User{}).RefTable() != users {
+		t.Fatal("failed to reuse the cached schemas")
+	}
+}
+
+// BenchmarkSession_Insert measures the Insert path, which looks up the schema of each value.
+func BenchmarkSession_Insert(b *testing.B) {
+	log.SetLevel(log.Disabled)
+	defer log.SetLevel(log.InfoLevel)
+	s := NewSessionForTest(b).Model(&User{})
+	_ = s.DropTable()
+	_ = s.CreateTable()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := s.Insert(user1); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkSession_Find measures the Find path, which looks up the schema of the slice element.
+func BenchmarkSession_Find(b *testing.B) {
+	log.SetLevel(log.Disabled)
+	defer log.SetLevel(log.InfoLevel)
+	s := NewSessionForTest(b).Model(&User{})
+	_ = s.DropTable()
+	_ = s.CreateTable()
+	_, _ = s.Insert(user1, user2, user3)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var users []User
+		if err := s.Find(&users); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// Customer is a model whose primary key is found by the ID convention.
+type Customer struct {
+	ID   int
+	Name string
+}
+
+// TestSession_GetSaveDeleteModel tests the primary-key based methods of Session.
+func TestSession_GetSaveDeleteModel(t *testing.T) {
+	s := NewSessionForTest(t).Model(&Customer{})
+	_ = s.DropTable()
+	_ = s.CreateTable()
+
+	// Save inserts a record which does not exist yet, then updates it.
+	if _, err := s.Save(&Customer{ID: 1, Name: "Tom"}); err != nil {
+		t.Fatal("failed to save new record", err)
+	}
+	if _, err := s.Save(&Customer{ID: 1, Name: "Tommy"}); err != nil {
+		t.Fatal("failed to save existing record", err)
+	}
+	// Saving unchanged values neither fails nor inserts a duplicate.
+	if _, err := s.Save(&Customer{ID: 1, Name: "Tommy"}); err != nil {
+		t.Fatal("failed to save unchanged record", err)
+	}
+
+	customer := &Customer{}
+	if err := s.Get(customer, 1); err != nil || customer.Name != "Tommy" {
+		t.Fatal("failed to get record", customer, err)
+	}
+	if count, _ := s.Count(); count != 1 {
+		t.Fatal("expected a single record, got", count)
+	}
+
+	if affected, err := s.DeleteModel(customer); err != nil || affected != 1 {
+		t.Fatal("failed to delete record", err)
+	}
+	if err := s.Get(&Customer{}, 1); err != ErrRecordNotFound {
+		t.Fatal("expected ErrRecordNotFound, got", err)
+	}
+	if err := s.Get(&User{}, "Tom"); err != ErrMissingPrimaryKey {
+		t.Fatal("expected ErrMissingPrimaryKey, got", err)
+	}
+}
+
+// Item is a model whose primary key is an integer ID by convention.
+type Item struct {
+	ID   int64
+	Name string
+}
+
+// TestSession_ConventionalID tests that a conventional integer ID is generated by the database.
+func TestSession_ConventionalID(t *testing.T) {
+	s := NewSessionForTest(t).Model(&Item{})
+	_ = s.DropTable()
+	_ = s.CreateTable()
+
+	first, second := &Item{Name: "pen"}, &Item{Name: "ink"}
+	if _, err := s.Save(first); err != nil || first.ID != 1 {
+		t.Fatal("failed to save new record", first, err)
+	}
+	if _, err := s.Save(second); err != nil || second.ID != 2 {
+		t.Fatal("failed to save second new record", second, err)
+	}
+}
+
+// TestSession_InsertBackfillsAutoIncrement tests that generated keys are written back after Insert.
+func TestSession_InsertBackfillsAutoIncrement(t *testing.T) {
+	s := NewSessionForTest(t).Model(&Ticket{})
+	_ = s.DropTable()
+	_ = s.CreateTable()
+
+	// A single-row insert reads the key from LastInsertId.
+	first := &Ticket{Title: "first"}
+	if _, err := s.Insert(first); err != nil || first.ID != 1 {
+		t.Fatal("failed to back-fill single insert", first, err)
+	}
+	// A multi-row insert with generated keys inserts and back-fills one row at a time.
+	second, third := &Ticket{Title: "second"}, &Ticket{Title: "third"}
+	if affected, err := s.Insert(second, third); err != nil || affected != 2 || second.ID != 2 || third.ID != 3 {
+		t.Fatal("failed to back-fill multi insert", second, third, err)
+	}
+	// An explicit key is inserted as given.
+	explicit := &Ticket{ID: 10, Title: "explicit"}
+	if _, err := s.Insert(explicit); err != nil || explicit.ID != 10 {
+		t.Fatal("failed to insert explicit key", explicit, err)
+	}
+	ticket := &Ticket{}
+	if err := s.Get(ticket, 10); err != nil || ticket.Title != "explicit" {
+		t.Fatal("failed to get explicit key", ticket, err)
+	}
+
+	// A mixed batch keeps explicit keys and back-fills each generated key into its own record.
+	generated, fixed, other := &Ticket{Title: "generated"}, &Ticket{ID: 20, Title: "fixed"}, &Ticket{Title: "other"}
+	if affected, err := s.Insert(generated, fixed, other); err != nil || affected != 3 {
+		t.Fatal("failed to insert mixed batch", affected, err)
+	}
+	for _, want := range []*Ticket{generated, fixed, other} {
+		got := &Ticket{}
+		if err := s.Get(got, want.ID); err != nil || got.Title != want.Title {
+			t.Fatal("failed to back-fill key of", want, got, err)
+		}
+	}
+	if fixed.ID != 20 || generated.ID == 0 || other.ID == 0 || generated.ID == other.ID {
+		t.Fatal("unexpected keys", generated, fixed, other)
+	}
+
+	// Inserting nothing is a no-op.
+	if affected, err := s.Insert(); err != nil || affected != 0 {
+		t.Fatal("expected empty insert to do nothing", affected, err)
+	}
+}
+
+// Post is a model with automatic timestamps.
+type Post struct {
+	ID        int
+	Title     string
+	CreatedAt time.Time
+	UpdatedAt int64
+}
+
+// TestSession_Timestamps tests that Insert, Update and Save fill timestamps from the session clock.
+func TestSession_Timestamps(t *testing.T) {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	s := NewSessionForTest(t)
+	WithNowFunc(func() time.Time { return now })(s)
+	s.Model(&Post{})
+	_ = s.DropTable()
+	_ = s.CreateTable()
+
+	post := &Post{ID: 1, Title: "hello"}
+	if _, err := s.Insert(post); err != nil || !post.CreatedAt.Equal(now) || post.UpdatedAt != now.Unix() {
+		t.Fatal("failed to fill timestamps on insert", post, err)
+	}
+
+	// Update sets UpdatedAt but leaves CreatedAt alone.
+	created := now
+	now = now.Add(time.Hour)
+	if _, err := s.Where("ID = ?", 1).Update("Title", "world"); err != nil {
+		t.Fatal("failed to update", err)
+	}
+	got := &Post{}
+	if err := s.Get(got, 1); err != nil || !got.CreatedAt.Equal(created) || got.UpdatedAt != now.Unix() {
+		t.Fatal("failed to fill timestamps on update", got, err)
+	}
+
+	// Save keeps the stored creation time when the record does not carry one.
+	now = now.Add(time.Hour)
+	saved := &Post{ID: 1, Title: "saved"}
+	if _, err := s.Save(saved); err != nil || saved.UpdatedAt != now.Unix() {
+		t.Fatal("failed to fill timestamps on save", saved, err)
+	}
+	if err := s.Get(got, 1); err != nil || !got.CreatedAt.Equal(created) || got.UpdatedAt != now.Unix() {
+		t.Fatal("failed to keep creation time on save", got, err)
+	}
+}
+
+// Member is a model which is soft-deleted.
+type Member struct {
+	ID        int
+	Name      string
+	DeletedAt *time.Time
+}
+
+// TestSession_SoftDelete tests that Delete marks records deleted and queries skip them.
+func TestSession_SoftDelete(t *testing.T) {
+	s := NewSessionForTest(t).Model(&Member{})
+	_ = s.DropTable()
+	_ = s.CreateTable()
+	_, _ = s.Insert(&Member{ID: 1, Name: "Tom"}, &Member{ID: 2, Name: "Sam"})
+
+	if affected, err := s.Where("Name = ? OR Name = ?", "Tom", "Nobody").Delete(); err != nil || affected != 1 {
+		t.Fatal("failed to soft delete", affected, err)
+	}
+	// The deleted record is hidden from Find, First, Count and Update.
+	var members []Member
+	if err := s.Find(&members); err != nil || len(members) != 1 || members[0].Name != "Sam" {
+		t.Fatal("failed to skip deleted record in Find", members, err)
+	}
+	if err := s.Get(&Member{}, 1); err != ErrRecordNotFound {
+		t.Fatal("expected ErrRecordNotFound, got", err)
+	}
+	if count, _ := s.Count(); count != 1 {
+		t.Fatal("failed to skip deleted record in Count", count)
+	}
+	if affected, _ := s.Where("ID = ?", 1).Update("Name", "Tommy"); affected != 0 {
+		t.Fatal("failed to skip deleted record in Update", affected)
+	}
+	// Unscoped includes the deleted record.
+	member := &Member{}
+	if err := s.Unscoped().Where("ID = ?", 1).First(member); err != nil || member.DeletedAt == nil {
+		t.Fatal("failed to find deleted record when unscoped", member, err)
+	}
+	if count, _ := s.Unscoped().Count(); count != 2 {
+		t.Fatal("failed to count deleted record when unscoped", count)
+	}
+
+	// Save reports a soft-deleted record instead of inserting a duplicate, and restores it when unscoped.
+	if _, err := s.Save(&Member{ID: 1, Name: "Tom"}); err != ErrRecordDeleted {
+		t.Fatal("expected ErrRecordDeleted, got", err)
+	}
+	if _, err := s.Unscoped().Save(&Member{ID: 1, Name: "Tom"}); err != nil {
+		t.Fatal("failed to restore deleted record", err)
+	}
+	if err := s.Get(&Member{}, 1); err != nil {
+		t.Fatal("failed to get restored record", err)
+	}
+
+	// HardDelete removes the record physically.
+	if affected, err := s.Where("ID = ?", 1).HardDelete(); err != nil || affected != 1 {
+		t.Fatal("failed to hard delete", affected, err)
+	}
+	if count, _ := s.Unscoped().Count(); count != 1 {
+		t.Fatal("failed to hard delete record", count)
+	}
+}
+
+// Invoice is a model with optimistic locking.
+type Invoice struct {
+	ID      int
+	Amount  int
+	Version int `tsorm:"version"`
+}
+
+// TestSession_OptimisticLocking tests that updating a stale model returns ErrStaleObject.
+func TestSession_OptimisticLocking(t *testing.T) {
+	s := NewSessionForTest(t).Model(&Invoice{})
+	_ = s.DropTable()
+	_ = s.CreateTable()
+
+	invoice := &Invoice{ID: 1, Amount: 100}
+	if _, err := s.Insert(invoice); err != nil || invoice.Version != 1 {
+		t.Fatal("failed to start version at 1", invoice, err)
+	}
+
+	// Two editors read the same version.
+	first, second := &Invoice{}, &Invoice{}
+	_ = s.Get(first, 1)
+	_ = s.Get(second, 1)
+
+	first.Amount = 200
+	if affected, err := s.Update(first); err != nil || affected != 1 || first.Version != 2 {
+		t.Fatal("failed to update current version", first, err)
+	}
+	second.Amount = 300
+	if _, err := s.Update(second); !errors.Is(err, ErrStaleObject) {
+		t.Fatal("expected ErrStaleObject, got", err)
+	}
+	if _, err := s.Save(second); !errors.Is(err, ErrStaleObject) {
+		t.Fatal("expected ErrStaleObject from Save, got", err)
+	}
+
+	// A key-value update bumps the version too.
+	if _, err := s.Where("ID = ?", 1).Update("Amount", 400); err != nil {
+		t.Fatal("failed to update", err)
+	}
+	got := &Invoice{}
+	if err := s.Get(got, 1); err != nil || got.Amount != 400 || got.Version != 3 {
+		t.Fatal("failed to bump version", got, err)
+	}
+	if _, err := s.Update(first); !errors.Is(err, ErrStaleObject) {
+		t.Fatal("expected ErrStaleObject after key-value update, got", err)
+	}
+}
+
+// Settings is a struct stored in a serialized column.
+type Settings struct {
+	Theme string
+	Tags  []string
+}
+
+// Preference is a model with serialized fields.
+type Preference struct {
+	ID       int
+	Labels   map[string]string `tsorm:"serializer:json"`
+	Settings Settings          `tsorm:"serializer:gob"`
+	Extra    *Settings         `tsorm:"serializer:json"`
+}
+
+// TestSession_Serializer tests that serialized fields survive a round trip through the database.
+func TestSession_Serializer(t *testing.T) {
+	s := NewSessionForTest(t).Model(&Preference{})
+	_ = s.DropTable()
+	_ = s.CreateTable()
+
+	expected := &Preference{
+		ID:       1,
+		Labels:   map[string]string{"env": "prod"},
+		Settings: Settings{Theme: "dark", Tags: []string{"a", "b"}},
+	}
+	if _, err := s.Insert(expected); err != nil {
+		t.Fatal("failed to insert serialized fields", err)
+	}
+	got := &Preference{}
+	if err := s.Get(got, 1); err != nil || !reflect.DeepEqual(got, expected) {
+		t.Fatal("failed to read serialized fields", got, err)
+	}
+
+	expected.Extra = &Settings{Theme: "light"}
+	if _, err := s.Save(expected); err != nil {
+		t.Fatal("failed to save serialized fields", err)
+	}
+	got = &Preference{}
+	if err := s.Get(got, 1); err != nil || !reflect.DeepEqual(got, expected) {
+		t.Fatal("failed to read saved serialized fields", got, err)
+	}
+
+	// Update encodes the values of serialized fields.
+	expected.Labels = map[string]string{"env": "dev"}
+	if _, err := s.Where("ID = ?", 1).Update("Labels", expected.Labels); err != nil {
+		t.Fatal("failed to update serialized field", err)
+	}
+	got = &Preference{}
+	if err := s.Get(got, 1); err != nil || !reflect.DeepEqual(got, expected) {
+		t.Fatal("failed to read updated serialized field", got, err)
+	}
+
+	// Nil values are stored as NULL.
+	if _, err := s.Where("ID = ?", 1).Update("Extra", (*Settings)(nil)); err != nil {
+		t.Fatal("failed to update serialized field to nil", err)
+	}
+	if count, _ := s.Where("Extra IS NULL").Count(); count != 1 {
+		t.Fatal("expected nil pointer to be stored as NULL")
+	}
+	if _, err := s.Insert(&Preference{ID: 2}); err != nil {
+		t.Fatal("failed to insert nil serialized fields", err)
+	}
+	if count, _ := s.Where("Labels IS NULL AND Extra IS NULL").Count(); count != 1 {
+		t.Fatal("expected nil map and pointer to be stored as NULL", count)
+	}
+}
+
+// Subscriber is a model with validation rules.
+type Subscriber struct {
+	ID    int
+	Email string `tsorm:"required;regex:^[^@]+@[^@]+$"`
+	Plan  string `tsorm:"oneof:free pro"`
+}
+
+// TestSession_Validation tests that invalid records are rejected before any statement runs.
+func TestSession_Validation(t *testing.T) {
+	s := NewSessionForTest(t).Model(&Subscriber{})
+	_ = s.DropTable()
+	_ = s.CreateTable()
+
+	var errs schema.ValidationErrors
+	if _, err := s.Insert(&Subscriber{ID: 1, Email: "tom@example.com", Plan: "free"}, &Subscriber{ID: 2, Plan: "gold"}); !errors.As(err, &errs) || len(errs) != 2 {
+		t.Fatal("expected two validation errors, got", err)
+	}
+	if count, _ := s.Count(); count != 0 {
+		t.Fatal("expected no record to be inserted, got", count)
+	}
+
+	if _, err := s.Insert(&Subscriber{ID: 1, Email: "tom@example.com", Plan: "free"}); err != nil {
+		t.Fatal("failed to insert valid record", err)
+	}
+	if _, err := s.Where("ID = ?", 1).Update("Plan", "gold"); !errors.As(err, &errs) {
+		t.Fatal("expected validation error on update, got", err)
+	}
+	// The conditions of a failed statement do not carry over to the next one.
+	if _, err := s.Unscoped().Where("ID = ?", 2).Update("Plan", "gold"); !errors.As(err, &errs) {
+		t.Fatal("expected validation error on update, got", err)
+	}
+	if count, _ := s.Count(); count != 1 {
+		t.Fatal("conditions of a failed update carried over, got", count)
+	}
+	if _, err := s.Save(&Subscriber{ID: 1, Email: "tom", Plan: "pro"}); !errors.As(err, &errs) {
+		t.Fatal("expected validation error on save, got", err)
+	}
+	got := &Subscriber{}
+	if err := s.Get(got, 1); err != nil || got.Plan != "free" || got.Email != "tom@example.com" {
+		t.Fatal("invalid update reached the database", got, err)
+	}
+}
+
+// Broken is a model with a field the dialect cannot store.
+type Broken struct {
+	ID      int
+	Options map[string]string
+}
+
+// TestSession_ModelE tests that statements return the error of a model which cannot be parsed.
+func TestSession_ModelE(t *testing.T) {
+	s := NewSessionForTest(t)
+	if _, err := s.ModelE(&Broken{}); err == nil {
+		t.Fatal("expected an error from ModelE")
+	}
+	if err := s.Model(&Broken{}).CreateTable(); err == nil || !strings.Contains(err.Error(), "Broken.Options") {
+		t.Fatal("expected CreateTable to return the model error, got", err)
+	}
+	if _, err := s.Insert(&Broken{ID: 1}); err == nil {
+		t.Fatal("expected Insert to return the model error")
+	}
+	var broken []Broken
+	if err := s.Find(&broken); err == nil {
+		t.Fatal("expected Find to return the model error")
+	}
+	if err := s.Find(&Broken{}); err == nil {
+		t.Fatal("expected Find to reject a non-slice destination")
+	}
+	if _, err := NewSessionForTest(t).Count(); err != ErrModelNotSet {
+		t.Fatal("expected ErrModelNotSet, got", err)
+	}
+}
+
+// TestSession_WhereExpr tests that Where, Or and Not accumulate typed and raw conditions.
+func TestSession_WhereExpr(t *testing.T) {
+	s := NewSessionForTest(t).Model(&User{})
+	_ = s.DropTable()
+	_ = s.CreateTable()
+	_, _ = s.Insert(&User{"Tom", 18}, &User{"Sam", 25}, &User{"Jack", 30})
+
+	names := func(s *Session) []string {
+		var users []User
+		if err := s.OrderBy("Name").Find(&users); err != nil {
+			t.Fatal("failed to query records", err)
+		}
+		var names []string
+		for _, u := range users {
+			names = append(names, u.Name)
+		}
+		return names
+	}
+
+	// Repeated Where calls are ANDed instead of overwriting each other.
+	if got := names(s.Where("Age > ?", 20).Where(expr.Lt("Age", 28))); !reflect.DeepEqual(got, []string{"Sam"}) {
+		t.Fatal("failed to AND conditions", got)
+	}
+	if got := names(s.Where(expr.Eq("Name", "Tom")).Or(expr.In("Age", []int{30}))); !reflect.DeepEqual(got, []string{"Jack", "Tom"}) {
+		t.Fatal("failed to OR conditions", got)
+	}
+	if got := names(s.Not(expr.Between("Age", 20, 40))); !reflect.DeepEqual(got, []string{"Tom"}) {
+		t.Fatal("failed to negate condition", got)
+	}
+	if got := names(s.Where(expr.Like("Name", "%a%")).Or("Age = ?", 18).Where(expr.Neq("Name", "Jack"))); !reflect.DeepEqual(got, []string{"Sam", "Tom"}) {
+		t.Fatal("failed to combine conditions", got)
+	}
+
+	// Args given with an expression are returned as an error by the next statement instead of dropped.
+	var users []User
+	if err := s.Where(expr.Eq("Name", "Tom"), "Sam").Find(&users); err == nil {
+		t.Fatal("expected an error for args given with an expression")
+	}
+	if got := names(s); len(got) != 3 {
+		t.Fatal("error of invalid conditions carried over", got)
+	}
+}
+
+// TestSession_ExpandSlices tests slice arguments to IN placeholders in Where and Raw.
+func TestSession_ExpandSlices(t *testing.T) {
+	s := NewSessionForTest(t).Model(&User{})
+	_ = s.DropTable()
+	_ = s.CreateTable()
+	_, _ = s.Insert(&User{"Tom", 18}, &User{"Sam", 25}, &User{"Jack", 30})
+
+	if count, err := s.Where("Name IN (?)", []string{"Tom", "Jack"}).Count(); err != nil || count != 2 {
+		t.Fatal("failed to expand slice in Where", count, err)
+	}
+	if count, err := s.Where("Age IN (?)", []int{}).Count(); err != nil || count != 0 {
+		t.Fatal("expected an empty slice to match nothing", count, err)
+	}
+	if count, err := s.Where("Age NOT IN (?)", []int{}).Count(); err != nil || count != 3 {
+		t.Fatal("expected NOT IN an empty slice to match everything", count, err)
+	}
+	if count, err := s.Not("Age IN (?)", []int{}).Count(); err != nil || count != 3 {
+		t.Fatal("expected Not IN an empty slice to match everything", count, err)
+	}
+	var count int64
+	if err := s.Raw("SELECT COUNT(*) FROM User WHERE Age IN (?) AND Name <> ?", []int{18, 25, 30}, "Sam").QueryRow().Scan(&count); err != nil || count != 2 {
+		t.Fatal("failed to expand slice in Raw", count, err)
+	}
+
+	// Raw placeholders are expanded, then rebound for dialects with numbered placeholders.
+	postgres, _ := dialect.GetDialect("postgres")
+	pg := NewSession(nil, postgres).Raw(`SELECT COUNT(*) FROM "User" WHERE "Age" IN (?) AND "Name" <> ?`, []int{18, 25}, "Sam")
+	if sql := pg.statement(); sql != `SELECT COUNT(*) FROM "User" WHERE "Age" IN ($1, $2) AND "Name" <> $3 ` {
+		t.Fatal("failed to rebind Raw for postgres, got", sql)
+	}
+	if !reflect.DeepEqual(pg.sqlVars, []interface{}{18, 25, "Sam"}) {
+		t.Fatal("failed to expand Raw variables for postgres, got", pg.sqlVars)
+	}
+}
+
+// TestSession_Paginate tests Offset, Paginate and FindAndCount.
+func TestSession_Paginate(t *testing.T) {
+	s := NewSessionForTest(t).Model(&User{})
+	_ = s.DropTable()
+	_ = s.CreateTable()
+	for i := 1; i <= 5; i++ {
+		_, _ = s.Insert(&User{fmt.Sprint("user", i), i})
+	}
+
+	var users []User
+	if err := s.OrderBy("Age").Offset(3).Find(&users); err != nil || len(users) != 2 || users[0].Age != 4 {
+		t.Fatal("failed to skip records with Offset", users, err)
+	}
+
+	users = nil
+	total, err := s.Where("Age > ?", 1).OrderBy("Age").Paginate(2, 2).FindAndCount(&users)
+	if err != nil || total != 4 || len(users) != 2 || users[0].Age != 4 || users[1].Age != 5 {
+		t.Fatal("failed to find and count page", total, users, err)
+	}
+}
+
+// Contact is a model for selecting and omitting columns.
+type Contact struct {
+	ID    int
+	Name  string
+	Email string
+	Phone string `tsorm:"default:''"`
+}
+
+// TestSession_SelectOmit tests that Select and Omit restrict the columns read and written.
+func TestSession_SelectOmit(t *testing.T) {
+	s := NewSessionForTest(t).Model(&Contact{})
+	_ = s.DropTable()
+	_ = s.CreateTable()
+
+	// Insert writes only the selected columns.
+	if _, err := s.Select("ID", "Name", "Email").Insert(&Contact{1, "Tom", "tom@example.com", "123"}); err != nil {
+		t.Fatal("failed to insert selected columns", err)
+	}
+	got := &Contact{}
+	if err := s.Get(got, 1); err != nil || got.Phone != "" || got.Email != "tom@example.com" {
+		t.Fatal("failed to insert only selected columns", got, err)
+	}
+
+	// Find reads only the selected columns, leaving the other fields untouched.
+	contact := &Contact{Email: "kept"}
+	if err := s.Select("ID", "Name").Where("ID = ?", 1).First(contact); err != nil || contact.Name != "Tom" || contact.Email != "kept" {
+		t.Fatal("failed to read only selected columns", contact, err)
+	}
+	contact = &Contact{Email: "kept"}
+	if err := s.Omit("Email").Where("ID = ?", 1).First(contact); err != nil || contact.Name != "Tom" || contact.Email != "kept" {
+		t.Fatal("failed to omit column", contact, err)
+	}
+
+	// Struct-based updates write only the selected columns.
+	if _, err := s.Omit("Email").Update(&Contact{1, "Tommy", "", "456"}); err != nil {
+		t.Fatal("failed to update with omitted column", err)
+	}
+	if err := s.Get(got, 1); err != nil || got.Name != "Tommy" || got.Email != "tom@example.com" || got.Phone != "456" {
+		t.Fatal("failed to leave omitted column unchanged", got, err)
+	}
+
+	if err := s.Omit("ID", "Name", "Email", "Phone").Find(&[]Contact{}); err != ErrNoColumns {
+		t.Fatal("expected ErrNoColumns, got", err)
+	}
+}
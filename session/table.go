@@ -4,58 +4,156 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
-	"tsorm/log"
+	"tsorm/dialect"
 	"tsorm/schema"
 )
 
 // Model sets the model for the session.
+// Schemas are cached per model type, so only the first use of a type parses it.
+// If the model cannot be parsed, the error is logged and returned by the next statement needing the model;
+// use ModelE to get it immediately.
 func (s *Session) Model(value interface{}) *Session {
-	// If the reference table is not set or the type of the provided value differs from the reference table's type,
-	// parse the value and set the reference table.
-	if s.refTable == nil || reflect.TypeOf(value) != reflect.TypeOf(s.refTable) {
-		s.refTable = schema.Parse(value, s.dialect)
+	if _, err := s.ModelE(value); err != nil {
+		s.logger.Error(err)
 	}
 	return s
 }
 
+// ModelE sets the model for the session like Model, returning an error if the model cannot be parsed.
+func (s *Session) ModelE(value interface{}) (*Session, error) {
+	s.model = value
+	// Keep the reference table if the value has the same type; its cached Model is always a pointer.
+	if value != nil && s.refTable != nil {
+		modelType := reflect.TypeOf(value)
+		if modelType.Kind() == reflect.Ptr {
+			modelType = modelType.Elem()
+		}
+		if modelType == reflect.TypeOf(s.refTable.Model).Elem() {
+			return s, nil
+		}
+	}
+
+	table, err := schema.ParseCachedE(value, s.dialect, schema.WithNamer(s.namer))
+	s.refTable, s.modelErr = table, err
+	return s, err
+}
+
 // RefTable returns the reference table of the session.
 func (s *Session) RefTable() *schema.Schema {
 	// If the reference table is nil, log an error and return nil.
 	if s.refTable == nil {
-		log.Error("Model is not set")
+		s.logger.Error("Model is not set")
 	}
 	return s.refTable
 }
 
+// table returns the reference table of the session, or the reason why there is none
+// or why the conditions of the next statement are invalid.
+func (s *Session) table() (*schema.Schema, error) {
+	if s.condErr != nil {
+		return nil, s.condErr
+	}
+	if s.refTable != nil {
+		return s.refTable, nil
+	}
+	if s.modelErr != nil {
+		return nil, s.modelErr
+	}
+	return nil, ErrModelNotSet
+}
+
 // CreateTable creates a table in the database based on the schema of the reference table.
 func (s *Session) CreateTable() error {
+	if _, err := s.table(); err != nil {
+		return err
+	}
+	// Execute the SQL command to create the table.
+	_, err := s.Raw(s.createTableSQL()).Exec()
+	return err
+}
+
+// createTableSQL returns the CREATE TABLE statement for the schema of the reference table.
+func (s *Session) createTableSQL() string {
 	table := s.RefTable()
+	// A composite primary key is declared as a table constraint, a single one inline.
+	var primaryKeys []string
+	for _, field := range table.Fields {
+		if field.PrimaryKey {
+			primaryKeys = append(primaryKeys, s.dialect.Quote(field.Column))
+		}
+	}
+
 	var columns []string
 	// Construct column definitions for the table.
 	for _, field := range table.Fields {
-		columns = append(columns, fmt.Sprintf("%s %s %s", field.Name, field.Type, field.Tag))
+		columns = append(columns, s.columnSQL(field, len(primaryKeys) == 1))
 	}
-	desc := strings.Join(columns, ",")
-	// Execute the SQL command to create the table.
-	_, err := s.Raw(fmt.Sprintf("CREATE TABLE %s (%s);", table.Name, desc)).Exec()
-	return err
+	if len(primaryKeys) > 1 {
+		columns = append(columns, fmt.Sprintf("PRIMARY KEY (%s)", strings.Join(primaryKeys, ", ")))
+	}
+	desc := strings.Join(columns, ", ")
+	return fmt.Sprintf("CREATE TABLE %s (%s)", s.dialect.Quote(table.Name), desc)
+}
+
+// columnSQL returns the definition of the column of field in CREATE TABLE.
+// inlinePrimaryKey reports whether a primary key field is the only one of the table.
+func (s *Session) columnSQL(field *schema.Field, inlinePrimaryKey bool) string {
+	parts := []string{s.dialect.Quote(field.Column)}
+	if field.AutoIncrement {
+		parts = append(parts, s.dialect.AutoIncrementSQL(field.Type, inlinePrimaryKey))
+	} else {
+		parts = append(parts, field.Type)
+		if field.PrimaryKey && inlinePrimaryKey {
+			parts = append(parts, "PRIMARY KEY")
+		}
+	}
+	// Columns of non-nullable fields reject NULL.
+	if field.NotNull || !field.Nullable {
+		parts = append(parts, "NOT NULL")
+	}
+	if field.Unique {
+		parts = append(parts, "UNIQUE")
+	}
+	if field.Default != nil {
+		parts = append(parts, "DEFAULT "+*field.Default)
+	}
+	return strings.Join(parts, " ")
 }
 
 // DropTable drops the table from the database.
 func (s *Session) DropTable() error {
+	table, err := s.table()
+	if err != nil {
+		return err
+	}
 	// Execute the SQL command to drop the table if it exists.
-	_, err := s.Raw(fmt.Sprintf("DROP TABLE IF EXISTS %s", s.RefTable().Name)).Exec()
+	_, err = s.Raw(fmt.Sprintf("DROP TABLE IF EXISTS %s", s.dialect.Quote(table.Name))).Exec()
 	return err
 }
 
 // HasTable checks if the table exists in the database.
+// It returns false if the model is not set.
 func (s *Session) HasTable() bool {
+	table, err := s.table()
+	if err != nil {
+		return false
+	}
 	// Get the SQL command and its values to check table existence.
-	sql, values := s.dialect.TableExistSQL(s.RefTable().Name)
+	sql, values := s.dialect.TableExistSQL(table.Name)
 	// Query the database to check if the table exists.
 	row := s.Raw(sql, values...).QueryRow()
 	var temp string
 	_ = row.Scan(&temp)
 	// Return true if the scanned table name matches the reference table's name.
-	return temp == s.RefTable().Name
+	return temp == table.Name
+}
+
+// Columns returns the columns of the given table as reported by the database.
+func (s *Session) Columns(tableName string) ([]dialect.ColumnInfo, error) {
+	return s.dialect.Columns(s.Context(), s.DB(), tableName)
+}
+
+// Indexes returns the indexes of the given table as reported by the database.
+func (s *Session) Indexes(tableName string) ([]dialect.IndexInfo, error) {
+	return s.dialect.Indexes(s.Context(), s.DB(), tableName)
 }
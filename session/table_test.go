@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"testing"
 	"tsorm/dialect"
+	"tsorm/schema"
 
 	_ "github.com/mattn/go-sqlite3"
 )
@@ -20,7 +21,7 @@ var (
 )
 
 // NewSessionForTest creates a new session for testing purposes.
-func NewSessionForTest(t *testing.T) *Session {
+func NewSessionForTest(t testing.TB) *Session {
 	// Open a new SQLite database connection.
 	TestDB, err := sql.Open("sqlite3", "../ts.db")
 	if err != nil {
@@ -50,3 +51,103 @@ func TestSession_CreateDropHasTable(t *testing.T) {
 		t.Fatal("Failed to create table User")
 	}
 }
+
+// TestSession_CreateTableSQL tests the CREATE TABLE statement generated for the MySQL dialect.
+func TestSession_CreateTableSQL(t *testing.T) {
+	mysql, _ := dialect.GetDialect("mysql")
+	s := NewSession(nil, mysql).Model(&User{})
+
+	expected := "CREATE TABLE `User` (`Name` VARCHAR(255) NOT NULL, `Age` BIGINT NOT NULL)"
+	if sql := s.createTableSQL(); sql != expected {
+		t.Fatalf("got %s, want %s", sql, expected)
+	}
+}
+
+// OrderItem is a model mapped through a naming strategy.
+type OrderItem struct {
+	ID        int
+	ItemName  string
+	UnitPrice float64 `tsorm:"column:price"`
+}
+
+// TestSession_NamingStrategy tests that SQL uses mapped names while results map back to the Go fields.
+func TestSession_NamingStrategy(t *testing.T) {
+	s := NewSession(NewSessionForTest(t).db, TestDial, WithNamer(schema.NamingStrategy{TablePrefix: "shop_"})).Model(&OrderItem{})
+	if s.RefTable().Name != "shop_order_items" {
+		t.Fatal("unexpected table name", s.RefTable().Name)
+	}
+	_ = s.DropTable()
+	if err := s.CreateTable(); err != nil {
+		t.Fatal("failed to create table", err)
+	}
+	if _, err := s.Insert(&OrderItem{ID: 1, ItemName: "pen", UnitPrice: 1.5}); err != nil {
+		t.Fatal("failed to insert", err)
+	}
+	// Update accepts Go field names as well as column names.
+	if _, err := s.Where("item_name = ?", "pen").Update("UnitPrice", 2.5); err != nil {
+		t.Fatal("failed to update", err)
+	}
+
+	item := &OrderItem{}
+	if err := s.First(item); err != nil || item.ID != 1 || item.ItemName != "pen" || item.UnitPrice != 2.5 {
+		t.Fatal("failed to query", item, err)
+	}
+
+	// Aggregates and Group accept Go field names as well.
+	_, _ = s.Insert(&OrderItem{ID: 2, ItemName: "ink", UnitPrice: 1})
+	var total float64
+	if err := s.Sum("UnitPrice", &total); err != nil || total != 3.5 {
+		t.Fatal("failed to sum by field name", total, err)
+	}
+	if count, err := s.Group("ItemName").Count(); err != nil || count != 2 {
+		t.Fatal("failed to group by field name", count, err)
+	}
+}
+
+// Shipment is a model with a composite primary key.
+type Shipment struct {
+	OrderID int    `tsorm:"primaryKey"`
+	Line    int    `tsorm:"primaryKey"`
+	Carrier string `tsorm:"size:20;default:'post'"`
+}
+
+// Ticket is a model with an auto-increment primary key.
+type Ticket struct {
+	ID    int64  `tsorm:"primaryKey;autoIncrement"`
+	Title string `tsorm:"unique"`
+}
+
+// LineItem is a model with an auto-increment column in a composite primary key.
+type LineItem struct {
+	OrderID int   `tsorm:"primaryKey"`
+	ID      int64 `tsorm:"primaryKey;autoIncrement"`
+}
+
+// TestSession_CreateTableSQLTags tests the DDL generated from structured tags for every dialect.
+func TestSession_CreateTableSQLTags(t *testing.T) {
+	testCases := []struct {
+		dialect  string
+		model    interface{}
+		expected string
+	}{
+		{"sqlite3", &Shipment{}, `CREATE TABLE "Shipment" ("OrderID" integer NOT NULL, "Line" integer NOT NULL, "Carrier" text NOT NULL DEFAULT 'post', PRIMARY KEY ("OrderID", "Line"))`},
+		{"mysql", &Shipment{}, "CREATE TABLE `Shipment` (`OrderID` BIGINT NOT NULL, `Line` BIGINT NOT NULL, `Carrier` VARCHAR(20) NOT NULL DEFAULT 'post', PRIMARY KEY (`OrderID`, `Line`))"},
+		{"sqlite3", &Ticket{}, `CREATE TABLE "Ticket" ("ID" integer PRIMARY KEY AUTOINCREMENT NOT NULL, "Title" text NOT NULL UNIQUE)`},
+		{"mysql", &Ticket{}, "CREATE TABLE `Ticket` (`ID` BIGINT AUTO_INCREMENT PRIMARY KEY NOT NULL, `Title` VARCHAR(255) NOT NULL UNIQUE)"},
+		{"postgres", &Ticket{}, `CREATE TABLE "Ticket" ("ID" bigint GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY NOT NULL, "Title" text NOT NULL UNIQUE)`},
+		{"mysql", &LineItem{}, "CREATE TABLE `LineItem` (`OrderID` BIGINT NOT NULL, `ID` BIGINT AUTO_INCREMENT NOT NULL, PRIMARY KEY (`OrderID`, `ID`))"},
+		{"postgres", &LineItem{}, `CREATE TABLE "LineItem" ("OrderID" integer NOT NULL, "ID" bigint GENERATED BY DEFAULT AS IDENTITY NOT NULL, PRIMARY KEY ("OrderID", "ID"))`},
+	}
+
+	for _, tc := range testCases {
+		d, _ := dialect.GetDialect(tc.dialect)
+		if sql := NewSession(nil, d).Model(tc.model).createTableSQL(); sql != tc.expected {
+			t.Errorf("%s: got %s, want %s", tc.dialect, sql, tc.expected)
+		}
+	}
+
+	// SQLite cannot generate the keys of a column of a composite key.
+	if err := NewSessionForTest(t).Model(&LineItem{}).CreateTable(); err == nil {
+		t.Error("expected an error for an auto-increment column of a composite key")
+	}
+}
@@ -1,14 +1,12 @@
 package session
 
-import "tsorm/log"
-
-// Begin starts a transaction.
+// Begin starts a transaction bound to the context of the session.
 func (s *Session) Begin() (err error) {
 	// Log the beginning of the transaction.
-	log.Info("transaction begin")
+	s.logger.Info("transaction begin")
 	// Start the transaction.
-	if s.tx, err = s.db.Begin(); err != nil {
-		log.Error(err)
+	if s.tx, err = s.db.BeginTx(s.Context(), nil); err != nil {
+		s.logger.Error(err)
 		return
 	}
 	return
@@ -17,10 +15,10 @@ func (s *Session) Begin() (err error) {
 // Commit commits the transaction.
 func (s *Session) Commit() (err error) {
 	// Log the transaction commit.
-	log.Info("transaction commit")
+	s.logger.Info("transaction commit")
 	// Commit the transaction.
 	if err = s.tx.Commit(); err != nil {
-		log.Error(err)
+		s.logger.Error(err)
 	}
 	return
 }
@@ -28,10 +26,10 @@ func (s *Session) Commit() (err error) {
 // Rollback rolls back the transaction.
 func (s *Session) Rollback() (err error) {
 	// Log the transaction rollback.
-	log.Info("transaction rollback")
+	s.logger.Info("transaction rollback")
 	// Roll back the transaction.
 	if err = s.tx.Rollback(); err != nil {
-		log.Error(err)
+		s.logger.Error(err)
 	}
 	return
 }